@@ -1,12 +1,31 @@
 package helpers
 
-import (
-	"reflect"
-	"strings"
-)
+import "strings"
 
 // CheckInTag represent the helpers for check attribute inside of struct
 func CheckInTag(v interface{}, filterField, tag string) []string {
+	out, _ := LookupInTag(v, filterField, tag)
+
+	return out
+}
+
+// CheckMatchTag represent the helpers for check attribute match of struct
+func CheckMatchTag(v interface{}, field, tag string) string {
+	out, _ := LookupMatchTag(v, field, tag)
+
+	return out
+}
+
+// LookupInTag represent the helpers for check attribute inside of struct. The
+// fields of the embedded structs are included and the fields of the nested
+// structs are named by their dotted path e.g. "author.name". The error is
+// ErrInvalidModel when v is not a struct or a pointer to a struct.
+func LookupInTag(v interface{}, filterField, tag string) ([]string, error) {
+	fields, err := modelTagFields(v, tag)
+	if err != nil {
+		return []string{}, err
+	}
+
 	filterField = strings.Replace(filterField, " ", "", -1)
 	field := strings.Split(filterField, ",")
 
@@ -15,33 +34,26 @@ func CheckInTag(v interface{}, filterField, tag string) []string {
 	for _, f := range field {
 		mapField[f] = true
 	}
-	val := reflect.ValueOf(v)
-	for i := 0; i < val.Type().NumField(); i++ {
-		if tag, ok := val.Type().Field(i).Tag.Lookup(tag); ok {
-			if _, ok := mapField[tag]; ok {
-				if tag != "-" {
-					out = append(out, tag)
-				}
-			}
+	for _, f := range fields.list {
+		if _, ok := mapField[f.name]; ok {
+			out = append(out, f.name)
 		}
 	}
 
-	return out
+	return out, nil
 }
 
-// CheckMatchTag represent the helpers for check attribute match of struct
-func CheckMatchTag(v interface{}, field, tag string) string {
-	var out string
-	val := reflect.ValueOf(v)
-	for i := 0; i < val.Type().NumField(); i++ {
-		if tag, ok := val.Type().Field(i).Tag.Lookup(tag); ok {
-			if tag == field {
-				if tag != "-" {
-					out = tag
-				}
-			}
-		}
+// LookupMatchTag represent the helpers for check attribute match of struct,
+// with the same rules and error as LookupInTag.
+func LookupMatchTag(v interface{}, field, tag string) (string, error) {
+	fields, err := modelTagFields(v, tag)
+	if err != nil {
+		return "", err
 	}
 
-	return out
+	if f, ok := fields.byName[field]; ok {
+		return f.name, nil
+	}
+
+	return "", nil
 }
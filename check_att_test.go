@@ -1,7 +1,9 @@
 package helpers
 
 import (
+	"database/sql"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -38,3 +40,108 @@ func TestCheckMatchDBAtt(t *testing.T) {
 
 	assert.Equal(t, selectField, field)
 }
+
+func TestCheckAttConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			assert.Equal(t, "email", CheckMatchTag(UserModel{}, "email", "json"))
+			assert.Equal(t, []string{"id", "name"}, CheckInTag(UserModel{}, "name,id,deleted_at", "json"))
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkCheckInTag(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		CheckInTag(UserModel{}, UserSelectField, "db")
+	}
+}
+
+func BenchmarkCheckMatchTag(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		CheckMatchTag(UserModel{}, "created_at", "db")
+	}
+}
+
+func BenchmarkOrderByHandler(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		OrderByHandler("-created_at", "db", UserModel{})
+	}
+}
+
+type BaseModel struct {
+	ID        string    `json:"id" db:"id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+type AuthorModel struct {
+	Name string `json:"name" db:"name"`
+}
+
+type PostModel struct {
+	BaseModel
+	Title    string       `json:"title" db:"title"`
+	Author   *AuthorModel `json:"author,nested" db:"author,nested"`
+	Parent   *PostModel   `json:"parent" db:"parent"`
+	internal string       `db:"internal"`
+}
+
+func TestCheckInTagEmbeddedAndNested(t *testing.T) {
+	res := CheckInTag(&PostModel{}, "id,title,author,author.name,created_at,internal,parent,parent.id", "db")
+
+	assert.Equal(t, []string{"id", "created_at", "title", "author", "author.name", "parent", "internal"}, res)
+}
+
+type CommentModel struct {
+	ID     string         `json:"id" db:"id"`
+	Note   sql.NullString `json:"note" db:"note"`
+	Author *AuthorModel   `json:"author" db:"author"`
+	Post   PostModel      `json:"post" db:"post"`
+}
+
+func TestCheckInTagNestedOptIn(t *testing.T) {
+	res := CheckInTag(CommentModel{}, "id,note,author,author.name,post,post.title", "db")
+
+	assert.Equal(t, []string{"id", "note", "author", "post"}, res)
+	assert.Equal(t, "author", CheckMatchTag(CommentModel{}, "author", "db"))
+	assert.Equal(t, "", CheckMatchTag(CommentModel{}, "author.name", "db"))
+}
+
+func TestCheckMatchTagPointerModel(t *testing.T) {
+	field := CheckMatchTag(&UserModel{}, "email", "db")
+
+	assert.Equal(t, "email", field)
+}
+
+func TestCheckMatchTagShadowedField(t *testing.T) {
+	type Shadow struct {
+		BaseModel
+		Key string `db:"id"`
+	}
+
+	fields, err := modelTagFields(Shadow{}, "db")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Key", fields.byName["id"].field.Name)
+}
+
+func TestLookupTagInvalidModel(t *testing.T) {
+	for _, model := range []interface{}{nil, "user", 1, []UserModel{}} {
+		res, err := LookupInTag(model, "id", "db")
+		assert.Equal(t, ErrInvalidModel, err)
+		assert.Empty(t, res)
+
+		field, err := LookupMatchTag(model, "id", "db")
+		assert.Equal(t, ErrInvalidModel, err)
+		assert.Empty(t, field)
+
+		assert.NotPanics(t, func() {
+			CheckInTag(model, "id", "db")
+			CheckMatchTag(model, "id", "db")
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package helpers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dialect represent the SQL dialect used for render the generated fragments
+type Dialect interface {
+	// QuoteIdent returns the quoted identifier, each part of a dotted identifier is quoted.
+	QuoteIdent(name string) string
+	// Placeholder returns the placeholder for the n-th argument, starting from 1.
+	Placeholder(n int) string
+	// OrderTerm returns the order by term of the sort column.
+	OrderTerm(spec SortSpec) string
+	// Limit returns the limit and offset clause.
+	Limit(limit, offset int) string
+}
+
+var (
+	// Postgres is the Dialect for PostgreSQL.
+	Postgres Dialect = &sqlDialect{quote: `"`, numbered: true, nulls: true}
+	// MySQL is the Dialect for MySQL and MariaDB. The NULLS FIRST/LAST placement
+	// is emulated with an "IS NULL" term.
+	MySQL Dialect = &sqlDialect{quote: "`"}
+	// SQLite is the Dialect for SQLite 3.30 or later.
+	SQLite Dialect = &sqlDialect{quote: `"`, nulls: true}
+
+	// rawDialect is the Dialect without identifier quoting used by OrderBy.
+	rawDialect Dialect = &sqlDialect{numbered: true, nulls: true}
+)
+
+// sqlDialect is the Dialect implementation configured per database.
+type sqlDialect struct {
+	// quote is the identifier quote character, no quoting when empty.
+	quote string
+	// numbered reports whether the placeholders are numbered e.g. $1, otherwise "?".
+	numbered bool
+	// nulls reports whether NULLS FIRST/LAST is supported, otherwise it is emulated.
+	nulls bool
+}
+
+// QuoteIdent returns the quoted identifier, each part of a dotted identifier is quoted.
+func (d *sqlDialect) QuoteIdent(name string) string {
+	if d.quote == "" {
+		return name
+	}
+
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = d.quote + strings.ReplaceAll(part, d.quote, d.quote+d.quote) + d.quote
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// Placeholder returns the placeholder for the n-th argument, starting from 1.
+func (d *sqlDialect) Placeholder(n int) string {
+	if d.numbered {
+		return fmt.Sprintf("$%d", n)
+	}
+
+	return "?"
+}
+
+// OrderTerm returns the order by term of the sort column.
+func (d *sqlDialect) OrderTerm(spec SortSpec) string {
+	spec.Column = d.QuoteIdent(spec.Column)
+	if d.nulls || spec.Nulls == NullsDefault {
+		return spec.String()
+	}
+
+	nulls := "ASC"
+	if spec.Nulls == NullsFirst {
+		nulls = "DESC"
+	}
+
+	column := spec.Column
+	spec.Nulls = NullsDefault
+
+	return fmt.Sprintf("%s IS NULL %s, %s", column, nulls, spec)
+}
+
+// Limit returns the limit and offset clause.
+func (d *sqlDialect) Limit(limit, offset int) string {
+	return fmt.Sprintf("LIMIT %d OFFSET %d", limit, offset)
+}
+
+// RenderOrderBy returns the order by fragment of the sort columns in the dialect
+// e.g. `"created_at" DESC, "id" ASC`.
+func RenderOrderBy(d Dialect, specs []SortSpec) string {
+	orders := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		orders = append(orders, d.OrderTerm(spec))
+	}
+
+	return strings.Join(orders, ", ")
+}
+
+// RenderLimit returns the limit and offset clause in the dialect e.g. "LIMIT 10 OFFSET 20".
+func RenderLimit(d Dialect, limit, offset int) string {
+	return d.Limit(limit, offset)
+}
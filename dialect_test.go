@@ -0,0 +1,68 @@
+package helpers
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDialectQuoteIdent(t *testing.T) {
+	assert.Equal(t, `"order"`, Postgres.QuoteIdent("order"))
+	assert.Equal(t, `"author"."name"`, SQLite.QuoteIdent("author.name"))
+	assert.Equal(t, `"a""b"`, Postgres.QuoteIdent(`a"b`))
+	assert.Equal(t, "`user`", MySQL.QuoteIdent("user"))
+	assert.Equal(t, "`a``b`", MySQL.QuoteIdent("a`b"))
+}
+
+func TestDialectPlaceholder(t *testing.T) {
+	assert.Equal(t, "$3", Postgres.Placeholder(3))
+	assert.Equal(t, "?", MySQL.Placeholder(3))
+	assert.Equal(t, "?", SQLite.Placeholder(3))
+}
+
+func TestRenderOrderBy(t *testing.T) {
+	specs := []SortSpec{
+		{Column: "order", Direction: SortDesc, Nulls: NullsLast},
+		{Column: "user", Direction: SortAsc, Nulls: NullsFirst},
+		{Column: "id", Direction: SortAsc},
+	}
+
+	assert.Equal(t, `"order" DESC NULLS LAST, "user" ASC NULLS FIRST, "id" ASC`, RenderOrderBy(Postgres, specs))
+	assert.Equal(t, `"order" DESC NULLS LAST, "user" ASC NULLS FIRST, "id" ASC`, RenderOrderBy(SQLite, specs))
+	assert.Equal(t, "`order` IS NULL ASC, `order` DESC, `user` IS NULL DESC, `user` ASC, `id` ASC", RenderOrderBy(MySQL, specs))
+	assert.Equal(t, "order DESC NULLS LAST, user ASC NULLS FIRST, id ASC", OrderBy(specs))
+}
+
+func TestRenderLimit(t *testing.T) {
+	offset, perPage, _, _ := PaginationSetter("10", "3")
+
+	assert.Equal(t, "LIMIT 10 OFFSET 20", RenderLimit(Postgres, perPage, offset))
+}
+
+func TestListQueryRender(t *testing.T) {
+	query, _ := ParseListQuery(url.Values{"page": {"2"}, "sort": {"-id"}}, UserModel{}, "db")
+	assert.Equal(t, "ORDER BY `id` DESC LIMIT 10 OFFSET 10", query.Render(MySQL))
+
+	query, _ = ParseListQuery(url.Values{}, UserModel{}, "db")
+	assert.Equal(t, "LIMIT 10 OFFSET 0", query.Render(Postgres))
+}
+
+func TestKeysetDialect(t *testing.T) {
+	sort, _ := ParseSort("-name,id", "db", UserModel{})
+	keyset := &Keyset{Sort: sort, Tag: "db", Secret: []byte("secret"), Dialect: MySQL}
+
+	cursor, _ := keyset.NextCursor(UserModel{ID: "10", Name: "foo"})
+	query, err := keyset.Query(cursor, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "((`name` < ?) OR (`name` = ? AND `id` > ?))", query.Where)
+	assert.Equal(t, []interface{}{"foo", "foo", "10"}, query.Args)
+	assert.Equal(t, "`name` DESC, `id` ASC", query.OrderBy)
+}
+
+func TestProjectionRenderSelect(t *testing.T) {
+	p, _ := NewProjection(ArticleModel{}, "id,position")
+
+	assert.Equal(t, "`id`, `order`", p.RenderSelect(MySQL))
+}
@@ -0,0 +1,276 @@
+package helpers
+
+import (
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FilterOperator represent the comparison operator of a filter condition
+type FilterOperator string
+
+const (
+	// FilterEq matches the column equal to the value.
+	FilterEq FilterOperator = "eq"
+	// FilterNe matches the column not equal to the value.
+	FilterNe FilterOperator = "ne"
+	// FilterGt matches the column greater than the value.
+	FilterGt FilterOperator = "gt"
+	// FilterGte matches the column greater than or equal to the value.
+	FilterGte FilterOperator = "gte"
+	// FilterLt matches the column less than the value.
+	FilterLt FilterOperator = "lt"
+	// FilterLte matches the column less than or equal to the value.
+	FilterLte FilterOperator = "lte"
+	// FilterIn matches the column equal to one of the comma-separated values.
+	FilterIn FilterOperator = "in"
+	// FilterLike matches the column with the LIKE pattern.
+	FilterLike FilterOperator = "like"
+	// FilterIsNull matches the column is NULL for "true" and is not NULL for "false".
+	FilterIsNull FilterOperator = "isnull"
+)
+
+// filterOperators is the SQL operator of the binary filter operators.
+var filterOperators = map[FilterOperator]string{
+	FilterEq:   "=",
+	FilterNe:   "<>",
+	FilterGt:   ">",
+	FilterGte:  ">=",
+	FilterLt:   "<",
+	FilterLte:  "<=",
+	FilterLike: "LIKE",
+}
+
+// filterKey matches the filter parameter e.g. "filter[status]" or "filter[created_at][gte]".
+var filterKey = regexp.MustCompile(`^filter\[([^\[\]]+)\](?:\[([^\[\]]+)\])?$`)
+
+// timeLayouts is the list of the accepted layouts for the time.Time values.
+var timeLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02"}
+
+// FilterCondition represent a single validated filter condition
+type FilterCondition struct {
+	Column   string
+	Operator FilterOperator
+	// Value is the value converted into the model field type, a []interface{}
+	// for FilterIn and a bool for FilterIsNull.
+	Value interface{}
+}
+
+// FilterError represent the error for an invalid filter operator or value
+type FilterError struct {
+	// Param is the name of the failed parameter e.g. "filter[created_at][gte]".
+	Param string
+	// Value is the raw value of the failed parameter.
+	Value string
+	// Reason is the reason of the failure.
+	Reason string
+}
+
+// Error returns the error message e.g. "Invalid parameter filter[age]: not an int".
+func (e *FilterError) Error() string {
+	return fmt.Sprintf("Invalid parameter %s: %s", e.Param, e.Reason)
+}
+
+// ParseFilter represent the helpers for parse the filter parameters such as
+// "filter[status]=active" and "filter[created_at][gte]=2024-01-01" into the
+// list of FilterCondition. The columns are checked against the model tag and
+// the values are converted into the model field type. The error is an
+// *InvalidFieldsError for the unknown columns, a *FilterError or ErrInvalidModel.
+// When a field of the model is marked "filterable" in the tag, only the marked
+// fields are allowed.
+func ParseFilter(values url.Values, model interface{}, tag string) ([]FilterCondition, error) {
+	return ParseFilterWithMapping(values, model, TagMapping{External: tag, Internal: tag})
+}
+
+// ParseFilterWithMapping represent the helpers for parse the filter parameters
+// like ParseFilter, the parameters are named by the external tag e.g. "json"
+// and the columns are named by the internal tag e.g. "db" of the same struct field.
+func ParseFilterWithMapping(values url.Values, model interface{}, mapping TagMapping) ([]FilterCondition, error) {
+	modelFields, err := modelMappedFields(model, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	conditions := []FilterCondition{}
+	rejected := []string{}
+	for _, key := range keys {
+		match := filterKey.FindStringSubmatch(key)
+		if match == nil {
+			continue
+		}
+
+		field, ok := modelFields.lookup(match[1], TagOptionFilterable)
+		if !ok {
+			rejected = append(rejected, match[1])
+			continue
+		}
+
+		operator := FilterOperator(strings.ToLower(match[2]))
+		if operator == "" {
+			operator = FilterEq
+		}
+
+		for _, raw := range values[key] {
+			value, err := filterValue(operator, raw, field.internal.field.Type)
+			if err != nil {
+				return nil, &FilterError{Param: key, Value: raw, Reason: err.Error()}
+			}
+
+			conditions = append(conditions, FilterCondition{
+				Column:   field.internal.name,
+				Operator: operator,
+				Value:    value,
+			})
+		}
+	}
+
+	if len(rejected) > 0 {
+		return nil, &InvalidFieldsError{
+			Param:    "filter",
+			Rejected: rejected,
+			Allowed:  modelFields.allowedNames(TagOptionFilterable),
+		}
+	}
+
+	return conditions, nil
+}
+
+// BuildWhere returns the parameterized predicate of the filter conditions
+// joined with AND in the dialect, and its arguments. The placeholders are
+// numbered from startIndex.
+func BuildWhere(conditions []FilterCondition, d Dialect, startIndex int) (string, []interface{}) {
+	args := []interface{}{}
+	predicates := make([]string, 0, len(conditions))
+	for _, c := range conditions {
+		switch c.Operator {
+		case FilterIsNull:
+			if isNull, _ := c.Value.(bool); isNull {
+				predicates = append(predicates, fmt.Sprintf("%s IS NULL", d.QuoteIdent(c.Column)))
+			} else {
+				predicates = append(predicates, fmt.Sprintf("%s IS NOT NULL", d.QuoteIdent(c.Column)))
+			}
+		case FilterIn:
+			items, _ := c.Value.([]interface{})
+			placeholders := make([]string, 0, len(items))
+			for _, item := range items {
+				placeholders = append(placeholders, d.Placeholder(startIndex+len(args)))
+				args = append(args, item)
+			}
+
+			predicates = append(predicates, fmt.Sprintf("%s IN (%s)", d.QuoteIdent(c.Column), strings.Join(placeholders, ", ")))
+		default:
+			predicates = append(predicates, fmt.Sprintf(
+				"%s %s %s", d.QuoteIdent(c.Column), filterOperators[c.Operator], d.Placeholder(startIndex+len(args)),
+			))
+			args = append(args, c.Value)
+		}
+	}
+
+	return strings.Join(predicates, " AND "), args
+}
+
+// filterValue converts the raw value for the given operator and field type.
+func filterValue(operator FilterOperator, raw string, t reflect.Type) (interface{}, error) {
+	switch operator {
+	case FilterIsNull:
+		isNull, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("not a bool")
+		}
+
+		return isNull, nil
+	case FilterLike:
+		return raw, nil
+	case FilterIn:
+		items := []interface{}{}
+		for _, item := range strings.Split(raw, ",") {
+			value, err := coerceValue(strings.TrimSpace(item), t)
+			if err != nil {
+				return nil, err
+			}
+
+			items = append(items, value)
+		}
+
+		return items, nil
+	}
+
+	if _, ok := filterOperators[operator]; !ok {
+		return nil, fmt.Errorf("unknown operator %s", operator)
+	}
+
+	return coerceValue(raw, t)
+}
+
+// coerceValue converts the raw value into the given type.
+func coerceValue(raw string, t reflect.Type) (interface{}, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == reflect.TypeOf(time.Time{}) {
+		for _, layout := range timeLayouts {
+			if v, err := time.Parse(layout, raw); err == nil {
+				return v, nil
+			}
+		}
+
+		return nil, fmt.Errorf("not a valid time")
+	}
+
+	if reflect.PtrTo(t).Implements(reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()) {
+		v := reflect.New(t)
+		if err := v.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw)); err != nil {
+			return nil, fmt.Errorf("not a valid %s", t)
+		}
+
+		return v.Elem().Interface(), nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return reflect.ValueOf(raw).Convert(t).Interface(), nil
+	case reflect.Bool:
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("not a bool")
+		}
+
+		return reflect.ValueOf(v).Convert(t).Interface(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(raw, 10, t.Bits())
+		if err != nil {
+			return nil, fmt.Errorf("not an int")
+		}
+
+		return reflect.ValueOf(v).Convert(t).Interface(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, err := strconv.ParseUint(raw, 10, t.Bits())
+		if err != nil {
+			return nil, fmt.Errorf("not an unsigned int")
+		}
+
+		return reflect.ValueOf(v).Convert(t).Interface(), nil
+	case reflect.Float32, reflect.Float64:
+		v, err := strconv.ParseFloat(raw, t.Bits())
+		if err != nil {
+			return nil, fmt.Errorf("not a float")
+		}
+
+		return reflect.ValueOf(v).Convert(t).Interface(), nil
+	}
+
+	return nil, fmt.Errorf("unsupported field type %s", t)
+}
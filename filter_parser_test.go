@@ -0,0 +1,104 @@
+package helpers
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type OrderModel struct {
+	ID        int64      `db:"id"`
+	Status    string     `db:"status"`
+	Total     float64    `db:"total"`
+	Paid      bool       `db:"paid"`
+	CreatedAt time.Time  `db:"created_at"`
+	DeletedAt *time.Time `db:"deleted_at"`
+}
+
+func TestParseFilter(t *testing.T) {
+	values := url.Values{
+		"filter[status]":             {"active"},
+		"filter[created_at][gte]":    {"2024-01-01"},
+		"filter[id][in]":             {"1, 2,3"},
+		"filter[deleted_at][isnull]": {"true"},
+		"filter[total][lt]":          {"9.5"},
+		"filter[paid][ne]":           {"false"},
+		"page":                       {"2"},
+	}
+	conditions, err := ParseFilter(values, OrderModel{}, "db")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []FilterCondition{
+		{Column: "created_at", Operator: FilterGte, Value: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Column: "deleted_at", Operator: FilterIsNull, Value: true},
+		{Column: "id", Operator: FilterIn, Value: []interface{}{int64(1), int64(2), int64(3)}},
+		{Column: "paid", Operator: FilterNe, Value: false},
+		{Column: "status", Operator: FilterEq, Value: "active"},
+		{Column: "total", Operator: FilterLt, Value: 9.5},
+	}, conditions)
+
+	where, args := BuildWhere(conditions, Postgres, 1)
+	assert.Equal(t, `"created_at" >= $1 AND "deleted_at" IS NULL AND "id" IN ($2, $3, $4) AND "paid" <> $5 AND "status" = $6 AND "total" < $7`, where)
+	assert.Len(t, args, 7)
+
+	where, _ = BuildWhere(conditions, MySQL, 1)
+	assert.Equal(t, "`created_at` >= ? AND `deleted_at` IS NULL AND `id` IN (?, ?, ?) AND `paid` <> ? AND `status` = ? AND `total` < ?", where)
+}
+
+func TestParseFilterLike(t *testing.T) {
+	values := url.Values{"filter[status][like]": {"act%"}, "filter[deleted_at][isnull]": {"0"}}
+	conditions, err := ParseFilter(values, OrderModel{}, "db")
+	assert.NoError(t, err)
+
+	where, args := BuildWhere(conditions, SQLite, 3)
+	assert.Equal(t, `"deleted_at" IS NOT NULL AND "status" LIKE ?`, where)
+	assert.Equal(t, []interface{}{"act%"}, args)
+}
+
+func TestParseFilterUnknownField(t *testing.T) {
+	values := url.Values{"filter[x]": {"1"}}
+	_, err := ParseFilter(values, OrderModel{}, "db")
+
+	var fieldsErr *InvalidFieldsError
+	assert.True(t, errors.As(err, &fieldsErr))
+	assert.Equal(t, "filter", fieldsErr.Param)
+	assert.Equal(t, []string{"x"}, fieldsErr.Rejected)
+}
+
+func TestParseFilterNestedOptIn(t *testing.T) {
+	_, err := ParseFilter(url.Values{"filter[author.name]": {"bar"}}, CommentModel{}, "db")
+
+	var fieldsErr *InvalidFieldsError
+	assert.True(t, errors.As(err, &fieldsErr))
+	assert.Equal(t, []string{"author.name"}, fieldsErr.Rejected)
+
+	conditions, err := ParseFilter(url.Values{"filter[author.name]": {"bar"}}, PostModel{}, "db")
+	assert.NoError(t, err)
+	assert.Equal(t, []FilterCondition{{Column: "author.name", Operator: FilterEq, Value: "bar"}}, conditions)
+}
+
+func TestParseFilterInvalidValue(t *testing.T) {
+	tests := map[string]struct {
+		values  url.Values
+		wantMsg string
+	}{
+		"not an int":       {url.Values{"filter[id]": {"a"}}, "Invalid parameter filter[id]: not an int"},
+		"not a time":       {url.Values{"filter[created_at][lt]": {"yesterday"}}, "Invalid parameter filter[created_at][lt]: not a valid time"},
+		"not a bool":       {url.Values{"filter[deleted_at][isnull]": {"maybe"}}, "Invalid parameter filter[deleted_at][isnull]: not a bool"},
+		"not a float":      {url.Values{"filter[total][in]": {"1,x"}}, "Invalid parameter filter[total][in]: not a float"},
+		"unknown operator": {url.Values{"filter[id][between]": {"1"}}, "Invalid parameter filter[id][between]: unknown operator between"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseFilter(tt.values, OrderModel{}, "db")
+
+			var filterErr *FilterError
+			assert.True(t, errors.As(err, &filterErr))
+			assert.Equal(t, tt.wantMsg, err.Error())
+		})
+	}
+}
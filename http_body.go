@@ -0,0 +1,188 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"net/url"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+// ErrBodyNotReplayable is an error message when a streaming request body is read a second time.
+var ErrBodyNotReplayable = errors.New("request body cannot be replayed")
+
+// RequestBody is the interface for build the body of a request with its Content-Type.
+type RequestBody interface {
+	// ContentType returns the Content-Type of the body.
+	ContentType() string
+	// Reader returns a new reader of the body, called on every attempt of the
+	// request. A body which can be read only once returns ErrBodyNotReplayable
+	// on the next calls, the request is not retried then.
+	Reader() (io.Reader, error)
+}
+
+// bytesBody is a RequestBody buffered in memory, replayed on every attempt.
+type bytesBody struct {
+	contentType string
+	data        []byte
+	err         error
+}
+
+// ContentType returns the Content-Type of the body.
+func (b *bytesBody) ContentType() string {
+	return b.contentType
+}
+
+// Reader returns a new reader of the body.
+func (b *bytesBody) Reader() (io.Reader, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	return bytes.NewReader(b.data), nil
+}
+
+// JSONBody returns the RequestBody of the JSON encoding of v.
+func JSONBody(v interface{}) RequestBody {
+	data, err := json.Marshal(v)
+
+	return &bytesBody{contentType: "application/json", data: data, err: err}
+}
+
+// FormBody returns the RequestBody of the url-encoded form values.
+func FormBody(values url.Values) RequestBody {
+	return &bytesBody{contentType: "application/x-www-form-urlencoded", data: []byte(values.Encode())}
+}
+
+// streamBody is a RequestBody read once from an io.Reader without buffering.
+type streamBody struct {
+	contentType string
+	reader      io.Reader
+	used        int32
+}
+
+// StreamBody returns the RequestBody streamed from the reader without
+// buffering. The body can be read only once so the request is not retried.
+func StreamBody(r io.Reader, contentType string) RequestBody {
+	return &streamBody{contentType: contentType, reader: r}
+}
+
+// ContentType returns the Content-Type of the body.
+func (b *streamBody) ContentType() string {
+	return b.contentType
+}
+
+// Reader returns the reader of the body, ErrBodyNotReplayable after the first call.
+func (b *streamBody) Reader() (io.Reader, error) {
+	if !atomic.CompareAndSwapInt32(&b.used, 0, 1) {
+		return nil, ErrBodyNotReplayable
+	}
+
+	return b.reader, nil
+}
+
+// MultipartFile represent a file part of a multipart body
+type MultipartFile struct {
+	// Field is the form field name of the file.
+	Field string
+	// Filename is the file name sent with the part.
+	Filename string
+	// ContentType is the Content-Type of the part, application/octet-stream when empty.
+	ContentType string
+	// Reader is the content of the file.
+	Reader io.Reader
+}
+
+// multipartBody is a multipart/form-data RequestBody streamed through a pipe.
+type multipartBody struct {
+	boundary string
+	fields   map[string]string
+	files    []MultipartFile
+	used     int32
+}
+
+// MultipartBody returns the multipart/form-data RequestBody of the form fields
+// and the files. The parts are streamed without buffering the files, so the
+// body can be read only once and the request is not retried.
+func MultipartBody(fields map[string]string, files ...MultipartFile) RequestBody {
+	return &multipartBody{
+		boundary: multipart.NewWriter(io.Discard).Boundary(),
+		fields:   fields,
+		files:    files,
+	}
+}
+
+// ContentType returns the Content-Type of the body with its boundary.
+func (b *multipartBody) ContentType() string {
+	return "multipart/form-data; boundary=" + b.boundary
+}
+
+// Reader returns the reader of the body, ErrBodyNotReplayable after the first call.
+func (b *multipartBody) Reader() (io.Reader, error) {
+	if !atomic.CompareAndSwapInt32(&b.used, 0, 1) {
+		return nil, ErrBodyNotReplayable
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		_ = pw.CloseWithError(b.write(pw))
+	}()
+
+	return pr, nil
+}
+
+// write writes the parts of the body.
+func (b *multipartBody) write(w io.Writer) error {
+	writer := multipart.NewWriter(w)
+	if err := writer.SetBoundary(b.boundary); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(b.fields))
+	for key := range b.fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := writer.WriteField(key, b.fields[key]); err != nil {
+			return err
+		}
+	}
+
+	for _, file := range b.files {
+		contentType := file.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", `form-data; name="`+escapeQuotes(file.Field)+
+			`"; filename="`+escapeQuotes(file.Filename)+`"`)
+		header.Set("Content-Type", contentType)
+
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return err
+		}
+
+		if _, err := io.Copy(part, file.Reader); err != nil {
+			return err
+		}
+	}
+
+	return writer.Close()
+}
+
+// quoteEscaper escapes the quotes of a Content-Disposition parameter like mime/multipart.
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// escapeQuotes escapes the quotes of a Content-Disposition parameter.
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
@@ -0,0 +1,191 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func echoServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte(r.Header.Get("Content-Type") + "\n" + string(body)))
+	}))
+}
+
+func TestDoRequestJSONBody(t *testing.T) {
+	srv := echoServer(t)
+	defer srv.Close()
+
+	rs := ""
+	opt := &HttpOptions{
+		Url:    srv.URL,
+		Method: http.MethodPost,
+		Body:   JSONBody(map[string]string{"ping": "pong"}),
+	}
+
+	code, err := DoRequest(opt, &rs)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "application/json\n{\"ping\":\"pong\"}", rs)
+}
+
+func TestDoRequestJSONBodyError(t *testing.T) {
+	opt := &HttpOptions{
+		Url:    "http://localhost",
+		Method: http.MethodPost,
+		Body:   JSONBody(make(chan int)),
+	}
+
+	_, err := DoRequest(opt, nil)
+
+	assert.EqualError(t, err, "json: unsupported type: chan int")
+}
+
+func TestDoRequestFormBody(t *testing.T) {
+	srv := echoServer(t)
+	defer srv.Close()
+
+	rs := ""
+	opt := &HttpOptions{
+		Url:    srv.URL,
+		Method: http.MethodPost,
+		Body:   FormBody(url.Values{"b": {"2"}, "a": {"1 2"}}),
+	}
+
+	_, err := DoRequest(opt, &rs)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "application/x-www-form-urlencoded\na=1+2&b=2", rs)
+}
+
+func TestDoRequestBodyContentTypeHeader(t *testing.T) {
+	srv := echoServer(t)
+	defer srv.Close()
+
+	rs := ""
+	opt := &HttpOptions{
+		Url:     srv.URL,
+		Method:  http.MethodPost,
+		Headers: map[string]string{"Content-Type": "application/vnd.api+json"},
+		Body:    JSONBody(1),
+	}
+
+	_, err := DoRequest(opt, &rs)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "application/vnd.api+json\n1", rs)
+}
+
+func TestDoRequestMultipartBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		assert.NoError(t, err)
+		assert.Equal(t, "multipart/form-data", mediaType)
+
+		reader := multipart.NewReader(r.Body, params["boundary"])
+		parts := []string{}
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			assert.NoError(t, err)
+
+			content, _ := io.ReadAll(part)
+			parts = append(parts, part.FormName()+"|"+part.FileName()+"|"+
+				part.Header.Get("Content-Type")+"|"+string(content))
+		}
+
+		w.Write([]byte(strings.Join(parts, "\n")))
+	}))
+	defer srv.Close()
+
+	rs := ""
+	opt := &HttpOptions{
+		Url:    srv.URL,
+		Method: http.MethodPost,
+		Body: MultipartBody(
+			map[string]string{"name": "pong", "id": "1"},
+			MultipartFile{Field: "file", Filename: "ping.txt", ContentType: "text/plain", Reader: strings.NewReader("ping")},
+			MultipartFile{Field: "raw", Filename: `a"b.bin`, Reader: strings.NewReader("raw")},
+		),
+	}
+
+	_, err := DoRequest(opt, &rs)
+
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Join([]string{
+		"id|||1",
+		"name|||pong",
+		"file|ping.txt|text/plain|ping",
+		`raw|a"b.bin|application/octet-stream|raw`,
+	}, "\n"), rs)
+}
+
+func TestStreamBodyNotReplayable(t *testing.T) {
+	body := StreamBody(strings.NewReader("ping"), "text/plain")
+
+	r, err := body.Reader()
+	assert.NoError(t, err)
+	assert.NotNil(t, r)
+	assert.Equal(t, "text/plain", body.ContentType())
+
+	_, err = body.Reader()
+	assert.True(t, errors.Is(err, ErrBodyNotReplayable))
+}
+
+func TestDoRequestStreamBodyNotRetried(t *testing.T) {
+	srv, calls := flakyServer(1, http.StatusServiceUnavailable, "")
+	defer srv.Close()
+
+	opt := &HttpOptions{
+		Ctx:    context.Background(),
+		Url:    srv.URL,
+		Method: http.MethodPut,
+		Body:   StreamBody(strings.NewReader("pong"), "text/plain"),
+		Retry:  &RetryOptions{MaxAttempts: 3, BaseDelay: time.Millisecond},
+	}
+
+	code, err := DoRequest(opt, nil)
+
+	httpErr := &HTTPError{}
+	assert.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestDoRequestFormBodyRetried(t *testing.T) {
+	srv, calls := flakyServer(1, http.StatusServiceUnavailable, "")
+	defer srv.Close()
+
+	rs := &PingModel{}
+	opt := &HttpOptions{
+		Ctx:    context.Background(),
+		Url:    srv.URL,
+		Method: http.MethodPut,
+		Body:   FormBody(url.Values{"pong": {""}}),
+		Retry:  &RetryOptions{MaxAttempts: 3, BaseDelay: time.Millisecond},
+	}
+
+	_, err := DoRequest(opt, rs)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "pong=", rs.Ping)
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+}
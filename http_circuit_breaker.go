@@ -0,0 +1,304 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultCircuitFailureThreshold is the default number of consecutive failures opening a circuit.
+	defaultCircuitFailureThreshold = 5
+	// defaultCircuitCoolDown is the default time a circuit stays open before the probes.
+	defaultCircuitCoolDown = 30 * time.Second
+	// defaultCircuitHalfOpenProbes is the default number of probes of a half-open circuit.
+	defaultCircuitHalfOpenProbes = 1
+)
+
+// ErrCircuitOpen is an error message when a request is rejected by an open circuit.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState represent the state of the circuit of a host
+type CircuitState int
+
+const (
+	// CircuitClosed lets the requests through and counts the failures.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects the requests until the cool-down is over.
+	CircuitOpen
+	// CircuitHalfOpen lets a limited number of probes through to test the host.
+	CircuitHalfOpen
+)
+
+// String returns the name of the state e.g. "half-open".
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+
+	return fmt.Sprintf("CircuitState(%d)", int(s))
+}
+
+// CircuitOpenError represent the error for a request rejected by an open circuit
+type CircuitOpenError struct {
+	// Host is the host of the rejected request.
+	Host string
+	// RetryAfter is the remaining cool-down before the circuit lets a probe through.
+	RetryAfter time.Duration
+}
+
+// Error returns the error message e.g. "circuit breaker is open for host example.com".
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("%s for host %s", ErrCircuitOpen, e.Host)
+}
+
+// Is reports whether the target is ErrCircuitOpen.
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// CircuitBreakerOptions represent the configuration of a CircuitBreaker
+type CircuitBreakerOptions struct {
+	// FailureThreshold is the number of consecutive failures opening the circuit. 5 when zero.
+	FailureThreshold int
+	// CoolDown is the time the circuit stays open before the probes. 30s when zero.
+	CoolDown time.Duration
+	// HalfOpenProbes is the number of probes let through by a half-open circuit,
+	// the circuit is closed when all of them succeed. 1 when zero.
+	HalfOpenProbes int
+	// IsFailure reports whether the result of a request is a failure. By default
+	// the errors except the cancellations and the 5xx responses are failures.
+	IsFailure func(resp *http.Response, err error) bool
+	// OnStateChange is called when the circuit of a host changes its state.
+	OnStateChange func(host string, from, to CircuitState)
+}
+
+// circuit is the state of the circuit of a host.
+type circuit struct {
+	state     CircuitState
+	failures  int
+	openedAt  time.Time
+	probes    int
+	successes int
+	// generation is incremented on every state change.
+	generation uint64
+}
+
+// circuitTicket is the token of a request let through by a circuit, its
+// result is only recorded in the same generation of the circuit.
+type circuitTicket struct {
+	generation uint64
+	probe      bool
+}
+
+// stateChange is a pending call of OnStateChange.
+type stateChange struct {
+	from, to CircuitState
+}
+
+// CircuitBreaker represent the per-host circuit breaker of the outbound
+// requests, safe for concurrent use. It is plugged into a Client with
+// WithMiddleware(cb.Middleware()).
+type CircuitBreaker struct {
+	opts  CircuitBreakerOptions
+	mu    sync.Mutex
+	hosts map[string]*circuit
+	now   func() time.Time
+}
+
+// NewCircuitBreaker returns a new CircuitBreaker with the given options.
+func NewCircuitBreaker(opts CircuitBreakerOptions) *CircuitBreaker {
+	if opts.FailureThreshold <= 0 {
+		opts.FailureThreshold = defaultCircuitFailureThreshold
+	}
+
+	if opts.CoolDown <= 0 {
+		opts.CoolDown = defaultCircuitCoolDown
+	}
+
+	if opts.HalfOpenProbes <= 0 {
+		opts.HalfOpenProbes = defaultCircuitHalfOpenProbes
+	}
+
+	if opts.IsFailure == nil {
+		opts.IsFailure = isCircuitFailure
+	}
+
+	return &CircuitBreaker{opts: opts, hosts: make(map[string]*circuit), now: time.Now}
+}
+
+// State returns the state of the circuit of the host.
+func (cb *CircuitBreaker) State(host string) CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	c, ok := cb.hosts[host]
+	if !ok {
+		return CircuitClosed
+	}
+
+	if c.state == CircuitOpen && cb.now().Sub(c.openedAt) >= cb.opts.CoolDown {
+		return CircuitHalfOpen
+	}
+
+	return c.state
+}
+
+// Middleware returns the middleware that rejects the requests to a host with
+// an open circuit with a *CircuitOpenError, which is never retried.
+func (cb *CircuitBreaker) Middleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			host := req.URL.Host
+			ticket, err := cb.allow(host)
+			if err != nil {
+				if req.Body != nil {
+					req.Body.Close()
+				}
+				return nil, err
+			}
+
+			resp, err := next.RoundTrip(req)
+			cb.record(host, ticket, resp, err)
+
+			return resp, err
+		})
+	}
+}
+
+// allow reports whether a request to the host is let through and returns its
+// ticket, a half-open circuit takes a probe.
+func (cb *CircuitBreaker) allow(host string) (circuitTicket, error) {
+	cb.mu.Lock()
+
+	c, ok := cb.hosts[host]
+	if !ok {
+		c = &circuit{}
+		cb.hosts[host] = c
+	}
+
+	var changes []stateChange
+	if c.state == CircuitOpen {
+		remaining := cb.opts.CoolDown - cb.now().Sub(c.openedAt)
+		if remaining > 0 {
+			cb.mu.Unlock()
+			return circuitTicket{}, &CircuitOpenError{Host: host, RetryAfter: remaining}
+		}
+
+		changes = cb.setState(c, CircuitHalfOpen, changes)
+	}
+
+	ticket := circuitTicket{generation: c.generation}
+	if c.state == CircuitHalfOpen {
+		if c.probes >= cb.opts.HalfOpenProbes {
+			cb.mu.Unlock()
+			cb.notify(host, changes)
+			return circuitTicket{}, &CircuitOpenError{Host: host}
+		}
+
+		c.probes++
+		ticket.probe = true
+	}
+
+	cb.mu.Unlock()
+	cb.notify(host, changes)
+
+	return ticket, nil
+}
+
+// record updates the circuit of the host with the result of a request. The
+// result of a request let through before the last state change is ignored,
+// a half-open circuit only counts its probes.
+func (cb *CircuitBreaker) record(host string, ticket circuitTicket, resp *http.Response, err error) {
+	failure := cb.opts.IsFailure(resp, err)
+	canceled := err != nil && errors.Is(err, context.Canceled)
+
+	cb.mu.Lock()
+
+	c := cb.hosts[host]
+	if c.generation != ticket.generation {
+		cb.mu.Unlock()
+		return
+	}
+
+	var changes []stateChange
+	switch c.state {
+	case CircuitClosed:
+		switch {
+		case failure:
+			c.failures++
+			if c.failures >= cb.opts.FailureThreshold {
+				changes = cb.setState(c, CircuitOpen, changes)
+			}
+		case canceled:
+			// the request did not test the host, the failures are kept.
+		default:
+			c.failures = 0
+		}
+	case CircuitHalfOpen:
+		if !ticket.probe {
+			break
+		}
+
+		switch {
+		case failure:
+			changes = cb.setState(c, CircuitOpen, changes)
+		case canceled:
+			// the probe did not test the host, its slot is released.
+			c.probes--
+		default:
+			c.successes++
+			if c.successes >= cb.opts.HalfOpenProbes {
+				changes = cb.setState(c, CircuitClosed, changes)
+			}
+		}
+	}
+
+	cb.mu.Unlock()
+	cb.notify(host, changes)
+}
+
+// setState moves the circuit to the state and appends the change, the lock must be held.
+func (cb *CircuitBreaker) setState(c *circuit, state CircuitState, changes []stateChange) []stateChange {
+	changes = append(changes, stateChange{from: c.state, to: state})
+
+	c.state = state
+	c.generation++
+	c.failures = 0
+	c.probes = 0
+	c.successes = 0
+	if state == CircuitOpen {
+		c.openedAt = cb.now()
+	}
+
+	return changes
+}
+
+// notify calls OnStateChange for the changes, outside of the lock.
+func (cb *CircuitBreaker) notify(host string, changes []stateChange) {
+	if cb.opts.OnStateChange == nil {
+		return
+	}
+
+	for _, change := range changes {
+		cb.opts.OnStateChange(host, change.from, change.to)
+	}
+}
+
+// isCircuitFailure reports whether the result of a request is a failure, the
+// errors except the cancellations and the 5xx responses.
+func isCircuitFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled)
+	}
+
+	return resp.StatusCode >= http.StatusInternalServerError
+}
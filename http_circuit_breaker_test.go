@@ -0,0 +1,201 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stateChangeRecord struct {
+	host     string
+	from, to CircuitState
+}
+
+func newTestBreaker(opts CircuitBreakerOptions) (*CircuitBreaker, *time.Time, *[]stateChangeRecord) {
+	changes := &[]stateChangeRecord{}
+	opts.OnStateChange = func(host string, from, to CircuitState) {
+		*changes = append(*changes, stateChangeRecord{host, from, to})
+	}
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cb := NewCircuitBreaker(opts)
+	cb.now = func() time.Time { return now }
+
+	return cb, &now, changes
+}
+
+func roundTrip(rt http.RoundTripper, url string) (*http.Response, error) {
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	return rt.RoundTrip(req)
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	cb, now, changes := newTestBreaker(CircuitBreakerOptions{
+		FailureThreshold: 2,
+		CoolDown:         time.Minute,
+		HalfOpenProbes:   2,
+	})
+
+	status := http.StatusServiceUnavailable
+	calls := 0
+	rt := cb.Middleware()(RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return jsonResponse(status, `{}`)(), nil
+	}))
+
+	for i := 0; i < 2; i++ {
+		_, err := roundTrip(rt, "http://api.example.com/users")
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, CircuitOpen, cb.State("api.example.com"))
+	assert.Equal(t, CircuitClosed, cb.State("other.example.com"))
+
+	_, err := roundTrip(rt, "http://api.example.com/users")
+	circuitErr := &CircuitOpenError{}
+	assert.True(t, errors.As(err, &circuitErr))
+	assert.True(t, errors.Is(err, ErrCircuitOpen))
+	assert.Equal(t, "api.example.com", circuitErr.Host)
+	assert.Equal(t, time.Minute, circuitErr.RetryAfter)
+	assert.EqualError(t, err, "circuit breaker is open for host api.example.com")
+	assert.Equal(t, 2, calls)
+
+	*now = now.Add(time.Minute)
+	assert.Equal(t, CircuitHalfOpen, cb.State("api.example.com"))
+
+	// a failed probe opens the circuit again.
+	_, err = roundTrip(rt, "http://api.example.com/users")
+	assert.NoError(t, err)
+	assert.Equal(t, CircuitOpen, cb.State("api.example.com"))
+
+	*now = now.Add(time.Minute)
+	status = http.StatusOK
+	for i := 0; i < 2; i++ {
+		_, err = roundTrip(rt, "http://api.example.com/users")
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, CircuitClosed, cb.State("api.example.com"))
+
+	assert.Equal(t, []stateChangeRecord{
+		{"api.example.com", CircuitClosed, CircuitOpen},
+		{"api.example.com", CircuitOpen, CircuitHalfOpen},
+		{"api.example.com", CircuitHalfOpen, CircuitOpen},
+		{"api.example.com", CircuitOpen, CircuitHalfOpen},
+		{"api.example.com", CircuitHalfOpen, CircuitClosed},
+	}, *changes)
+}
+
+func TestCircuitBreakerHalfOpenProbes(t *testing.T) {
+	cb, now, _ := newTestBreaker(CircuitBreakerOptions{FailureThreshold: 1, CoolDown: time.Second})
+
+	ticket, err := cb.allow("host")
+	assert.NoError(t, err)
+	cb.record("host", ticket, nil, errors.New("connection refused"))
+	assert.Equal(t, CircuitOpen, cb.State("host"))
+
+	*now = now.Add(time.Second)
+	probe, err := cb.allow("host")
+	assert.NoError(t, err)
+	assert.True(t, probe.probe)
+	_, err = cb.allow("host")
+	assert.True(t, errors.Is(err, ErrCircuitOpen))
+
+	// a canceled probe releases its slot.
+	cb.record("host", probe, nil, context.Canceled)
+	_, err = cb.allow("host")
+	assert.NoError(t, err)
+}
+
+func TestCircuitBreakerStaleResult(t *testing.T) {
+	cb, now, _ := newTestBreaker(CircuitBreakerOptions{FailureThreshold: 1, CoolDown: time.Second})
+
+	slow, err := cb.allow("host")
+	assert.NoError(t, err)
+	failed, err := cb.allow("host")
+	assert.NoError(t, err)
+	cb.record("host", failed, nil, errors.New("connection refused"))
+	assert.Equal(t, CircuitOpen, cb.State("host"))
+
+	*now = now.Add(time.Second)
+	probe, err := cb.allow("host")
+	assert.NoError(t, err)
+
+	// the slow success let through while closed does not close the circuit.
+	cb.record("host", slow, &http.Response{StatusCode: http.StatusOK}, nil)
+	assert.Equal(t, CircuitHalfOpen, cb.State("host"))
+
+	// a canceled request which is not a probe does not release a probe slot.
+	cb.record("host", circuitTicket{generation: probe.generation}, nil, context.Canceled)
+	_, err = cb.allow("host")
+	assert.True(t, errors.Is(err, ErrCircuitOpen))
+
+	cb.record("host", probe, &http.Response{StatusCode: http.StatusOK}, nil)
+	assert.Equal(t, CircuitClosed, cb.State("host"))
+}
+
+func TestCircuitBreakerSuccessResetsFailures(t *testing.T) {
+	cb, _, _ := newTestBreaker(CircuitBreakerOptions{FailureThreshold: 2})
+
+	for _, status := range []int{http.StatusBadGateway, http.StatusOK, http.StatusBadGateway} {
+		ticket, err := cb.allow("host")
+		assert.NoError(t, err)
+		cb.record("host", ticket, &http.Response{StatusCode: status}, nil)
+	}
+
+	assert.Equal(t, CircuitClosed, cb.State("host"))
+}
+
+func TestCircuitBreakerCanceledKeepsFailures(t *testing.T) {
+	cb, _, _ := newTestBreaker(CircuitBreakerOptions{FailureThreshold: 2})
+
+	for _, err := range []error{errors.New("connection refused"), context.Canceled, errors.New("connection refused")} {
+		ticket, allowErr := cb.allow("host")
+		assert.NoError(t, allowErr)
+		cb.record("host", ticket, nil, err)
+	}
+
+	assert.Equal(t, CircuitOpen, cb.State("host"))
+}
+
+func TestCircuitBreakerOpenClosesBody(t *testing.T) {
+	cb, _, _ := newTestBreaker(CircuitBreakerOptions{FailureThreshold: 1})
+	rt := cb.Middleware()(RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	}))
+	_, _ = roundTrip(rt, "http://api.example.com")
+
+	body := &closerBody{}
+	req, _ := http.NewRequest(http.MethodPost, "http://api.example.com", body)
+	_, err := rt.RoundTrip(req)
+
+	assert.True(t, errors.Is(err, ErrCircuitOpen))
+	assert.True(t, body.closed)
+}
+
+func TestClientCircuitBreakerNotRetried(t *testing.T) {
+	cb, _, _ := newTestBreaker(CircuitBreakerOptions{FailureThreshold: 1})
+	mock := &mockedHTTPClient{err: errors.New("connection refused")}
+	c, err := NewClient(
+		WithHTTPClient(mock),
+		WithMiddleware(cb.Middleware()),
+		WithRetry(&RetryOptions{MaxAttempts: 3, BaseDelay: time.Millisecond}),
+	)
+	assert.NoError(t, err)
+
+	code, err := c.DoRequest(&HttpOptions{Url: "http://api.example.com", Method: http.MethodGet}, nil)
+
+	circuitErr := &CircuitOpenError{}
+	assert.True(t, errors.As(err, &circuitErr))
+	assert.Equal(t, http.StatusInternalServerError, code)
+	assert.Len(t, mock.requests, 1)
+}
+
+func TestCircuitStateString(t *testing.T) {
+	assert.Equal(t, "closed", CircuitClosed.String())
+	assert.Equal(t, "open", CircuitOpen.String())
+	assert.Equal(t, "half-open", CircuitHalfOpen.String())
+	assert.Equal(t, "CircuitState(9)", CircuitState(9).String())
+}
@@ -0,0 +1,259 @@
+package helpers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptrace"
+	"time"
+)
+
+var (
+	// errFailedSetHTTPClient is an error message when failed to set http client.
+	errFailedSetHTTPClient = errors.New("failed to set client.http_client")
+	// errFailedSetTransport is an error message when failed to set transport.
+	errFailedSetTransport = errors.New("failed to set client.transport")
+	// errFailedSetTLSConfig is an error message when failed to set tls config.
+	errFailedSetTLSConfig = errors.New("failed to set client.tls_config")
+	// errFailedSetProxy is an error message when failed to set proxy.
+	errFailedSetProxy = errors.New("failed to set client.proxy")
+	// errFailedSetDecoder is an error message when failed to set decoder.
+	errFailedSetDecoder = errors.New("failed to set client.decoder")
+	// errFailedSetMaxIdleConns is an error message when failed to set max idle connections.
+	errFailedSetMaxIdleConns = errors.New("failed to set client.max_idle_conns_per_host")
+	// errFailedSetTimeouts is an error message when failed to set timeouts.
+	errFailedSetTimeouts = errors.New("failed to set client.timeouts")
+	// errFailedSetMiddleware is an error message when failed to set middleware.
+	errFailedSetMiddleware = errors.New("failed to set client.middleware")
+	// errInternal is an error message for internal error.
+	errInternal = errors.New("internal error")
+)
+
+// HTTPClient is the interface for send the http request, implemented by *http.Client.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client represent the reusable http client for DoRequest
+type Client struct {
+	httpClient   HTTPClient
+	transport    *http.Transport
+	roundTripper http.RoundTripper
+	retry        *RetryOptions
+	decoders     map[string]Decoder
+	timeout      time.Duration
+	middlewares  []Middleware
+	// handler is the http client wrapped by the middlewares.
+	handler http.RoundTripper
+}
+
+// Timeouts represent the timeouts of the client, no timeout when zero.
+type Timeouts struct {
+	// Connect is the timeout for establish the TCP connection.
+	Connect time.Duration
+	// TLSHandshake is the timeout for the TLS handshake.
+	TLSHandshake time.Duration
+	// ResponseHeader is the timeout for receive the response headers after the request is sent.
+	ResponseHeader time.Duration
+	// Total is the default total timeout of a request including the retries.
+	Total time.Duration
+}
+
+// defaultClient is the Client used by DoRequest.
+var defaultClient = &Client{httpClient: http.DefaultClient}
+
+func wrapErr(err1 error, err2 error) error {
+	return fmt.Errorf("%v: %w", err1, err2)
+}
+
+// NewClient returns a new Client. Without WithHTTPClient, the http client is
+// built on a clone of http.DefaultTransport tuned by the transport options.
+func NewClient(opts ...ClientOption) (*Client, error) {
+	c := &Client{transport: http.DefaultTransport.(*http.Transport).Clone()}
+
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, fmt.Errorf("failed to apply option: %w", wrapErr(err, errInternal))
+		}
+	}
+
+	if c.httpClient == nil {
+		var rt http.RoundTripper = c.transport
+		if c.roundTripper != nil {
+			rt = c.roundTripper
+		}
+
+		c.httpClient = &http.Client{Transport: rt}
+	}
+
+	if len(c.middlewares) > 0 {
+		c.handler = Chain(RoundTripperFunc(c.httpClient.Do), c.middlewares...)
+	}
+
+	return c, nil
+}
+
+// DoRequest sends the request of the options and decodes the response into rs.
+func DoRequest(opt *HttpOptions, rs interface{}) (int, error) {
+	return defaultClient.DoRequest(opt, rs)
+}
+
+// DoRequest sends the request of the options and decodes the response into rs
+// like Execute. It returns the status code of the response, or 500 for the
+// failures except an *HTTPError.
+func (c *Client) DoRequest(opt *HttpOptions, rs interface{}) (int, error) {
+	resp, err := c.Execute(opt, rs)
+
+	var httpErr *HTTPError
+	if err != nil && !errors.As(err, &httpErr) {
+		return http.StatusInternalServerError, err
+	}
+
+	return resp.StatusCode, err
+}
+
+// Execute sends the request of the options and decodes the response into rs
+// by its content type, JSON by default. The body is streamed when rs is an
+// io.Writer and an empty body is not decoded. The retry configuration of the
+// client is used when the options have none. The Response is returned once a
+// response is received, even with an error, with the raw body when
+// HttpOptions.KeepRawBody is set.
+//
+// A 4xx or 5xx response is not decoded into rs, it returns an *HTTPError and
+// its body is decoded into HttpOptions.ErrorTarget when set. The other
+// failures are a *TransportError, a *TimeoutError or a *DecodeError.
+func (c *Client) Execute(opt *HttpOptions, rs interface{}) (*Response, error) {
+	ctx, cancel := opt.context(c.timeout)
+	defer cancel()
+
+	retry := opt.Retry
+	if retry == nil {
+		retry = c.retry
+	}
+
+	trace := &timingTrace{}
+	traceCtx := httptrace.WithClientTrace(ctx, trace.clientTrace())
+
+	start := time.Now()
+	resp, err := doWithRetry(ctx, retry, opt.Method, func() (*http.Request, error) {
+		return opt.newRequest(traceCtx)
+	}, c.send)
+	if err != nil {
+		if err == ctx.Err() {
+			err = transportError(err)
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := newResponse(resp)
+	defer func() {
+		response.Duration = time.Since(start)
+		response.Timing = trace.timing()
+	}()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		err = c.httpError(opt, resp)
+
+		var httpErr *HTTPError
+		if opt.KeepRawBody && errors.As(err, &httpErr) {
+			response.Body = httpErr.Body
+		}
+		return response, err
+	}
+
+	if rs == nil {
+		if opt.KeepRawBody {
+			response.Body, err = ioutil.ReadAll(resp.Body)
+			if err != nil {
+				return response, transportError(err)
+			}
+		}
+		return response, nil
+	}
+
+	if w, ok := rs.(io.Writer); ok {
+		raw := &bytes.Buffer{}
+		if opt.KeepRawBody {
+			w = io.MultiWriter(w, raw)
+		}
+
+		_, err = io.Copy(w, resp.Body)
+		if opt.KeepRawBody {
+			response.Body = raw.Bytes()
+		}
+		if err != nil {
+			return response, transportError(err)
+		}
+		return response, nil
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return response, transportError(err)
+	}
+
+	if opt.KeepRawBody {
+		response.Body = respBody
+	}
+
+	if len(respBody) == 0 {
+		return response, nil
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	err = c.decoderFor(opt, contentType, rs).Decode(respBody, rs)
+	if err != nil {
+		return response, &DecodeError{ContentType: contentType, Err: err}
+	}
+	return response, nil
+}
+
+// send sends the request with the http client through the middlewares and
+// classifies its error.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	var (
+		resp *http.Response
+		err  error
+	)
+	if c.handler != nil {
+		resp, err = c.handler.RoundTrip(req)
+	} else {
+		resp, err = c.httpClient.Do(req)
+	}
+	if err != nil {
+		return nil, transportError(err)
+	}
+
+	if resp.Request == nil {
+		resp.Request = req
+	}
+
+	return resp, nil
+}
+
+// httpError returns the *HTTPError of the response and decodes its body into
+// HttpOptions.ErrorTarget when set. A failure of the error body decoding is
+// ignored, the raw body is kept in the *HTTPError.
+func (c *Client) httpError(opt *HttpOptions, resp *http.Response) error {
+	httpErr := &HTTPError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Header:     resp.Header,
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return transportError(err)
+	}
+	httpErr.Body = body
+
+	if opt.ErrorTarget != nil && len(body) > 0 {
+		_ = c.decoderFor(opt, resp.Header.Get("Content-Type"), opt.ErrorTarget).Decode(body, opt.ErrorTarget)
+	}
+
+	return httpErr
+}
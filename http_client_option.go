@@ -0,0 +1,149 @@
+package helpers
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ClientOption configures Client.
+type ClientOption func(c *Client) error
+
+// WithHTTPClient returns an option that set the http client. The transport
+// options are ignored when the http client is set.
+func WithHTTPClient(httpClient HTTPClient) ClientOption {
+	return func(c *Client) error {
+		if httpClient == nil {
+			return errFailedSetHTTPClient
+		}
+
+		c.httpClient = httpClient
+
+		return nil
+	}
+}
+
+// WithTransport returns an option that set the round tripper of the http client.
+// The other transport options are ignored when the round tripper is set.
+func WithTransport(rt http.RoundTripper) ClientOption {
+	return func(c *Client) error {
+		if rt == nil {
+			return errFailedSetTransport
+		}
+
+		c.roundTripper = rt
+
+		return nil
+	}
+}
+
+// WithTLSConfig returns an option that set the tls config of the transport.
+func WithTLSConfig(cfg *tls.Config) ClientOption {
+	return func(c *Client) error {
+		if cfg == nil {
+			return errFailedSetTLSConfig
+		}
+
+		c.transport.TLSClientConfig = cfg
+
+		return nil
+	}
+}
+
+// WithProxy returns an option that set the proxy function of the transport e.g. http.ProxyURL.
+func WithProxy(proxy func(*http.Request) (*url.URL, error)) ClientOption {
+	return func(c *Client) error {
+		if proxy == nil {
+			return errFailedSetProxy
+		}
+
+		c.transport.Proxy = proxy
+
+		return nil
+	}
+}
+
+// WithMaxIdleConnsPerHost returns an option that set the maximum idle connections kept per host.
+func WithMaxIdleConnsPerHost(n int) ClientOption {
+	return func(c *Client) error {
+		if n < 1 {
+			return errFailedSetMaxIdleConns
+		}
+
+		c.transport.MaxIdleConnsPerHost = n
+
+		return nil
+	}
+}
+
+// WithRetry returns an option that set the default retry configuration of the
+// requests without HttpOptions.Retry.
+func WithRetry(retry *RetryOptions) ClientOption {
+	return func(c *Client) error {
+		c.retry = retry
+
+		return nil
+	}
+}
+
+// WithDecoder returns an option that set the Decoder of the responses with the
+// given media type e.g. "application/vnd.api+json".
+func WithDecoder(mediaType string, d Decoder) ClientOption {
+	return func(c *Client) error {
+		if mediaType == "" || d == nil {
+			return errFailedSetDecoder
+		}
+
+		if c.decoders == nil {
+			c.decoders = make(map[string]Decoder)
+		}
+
+		c.decoders[mediaType] = d
+
+		return nil
+	}
+}
+
+// WithTimeouts returns an option that set the timeouts of the client. The
+// connect, TLS handshake and response header timeouts are set on the transport.
+func WithTimeouts(timeouts Timeouts) ClientOption {
+	return func(c *Client) error {
+		if timeouts.Connect < 0 || timeouts.TLSHandshake < 0 || timeouts.ResponseHeader < 0 || timeouts.Total < 0 {
+			return errFailedSetTimeouts
+		}
+
+		if timeouts.Connect > 0 {
+			c.transport.DialContext = (&net.Dialer{
+				Timeout:   timeouts.Connect,
+				KeepAlive: 30 * time.Second,
+			}).DialContext
+		}
+
+		if timeouts.TLSHandshake > 0 {
+			c.transport.TLSHandshakeTimeout = timeouts.TLSHandshake
+		}
+
+		c.transport.ResponseHeaderTimeout = timeouts.ResponseHeader
+		c.timeout = timeouts.Total
+
+		return nil
+	}
+}
+
+// WithMiddleware returns an option that append the middlewares to the chain of
+// the client, they are called in the given order on every attempt of a request.
+func WithMiddleware(middlewares ...Middleware) ClientOption {
+	return func(c *Client) error {
+		for _, m := range middlewares {
+			if m == nil {
+				return errFailedSetMiddleware
+			}
+		}
+
+		c.middlewares = append(c.middlewares, middlewares...)
+
+		return nil
+	}
+}
@@ -0,0 +1,148 @@
+package helpers
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientOptions(t *testing.T) {
+	proxyURL, _ := url.Parse("http://proxy.example.com")
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	transport := &http.Transport{}
+
+	type test struct {
+		opt     ClientOption
+		check   func(t *testing.T, c *Client)
+		wantErr error
+	}
+
+	tests := map[string]func(t *testing.T) test{
+		"Successfully set http client": func(t *testing.T) test {
+			t.Helper()
+
+			return test{
+				opt: WithHTTPClient(http.DefaultClient),
+				check: func(t *testing.T, c *Client) {
+					assert.Equal(t, http.DefaultClient, c.httpClient)
+				},
+			}
+		},
+		"Failed set http client": func(t *testing.T) test {
+			t.Helper()
+
+			return test{opt: WithHTTPClient(nil), wantErr: errFailedSetHTTPClient}
+		},
+		"Successfully set transport": func(t *testing.T) test {
+			t.Helper()
+
+			return test{
+				opt: WithTransport(transport),
+				check: func(t *testing.T, c *Client) {
+					assert.Equal(t, transport, c.roundTripper)
+				},
+			}
+		},
+		"Failed set transport": func(t *testing.T) test {
+			t.Helper()
+
+			return test{opt: WithTransport(nil), wantErr: errFailedSetTransport}
+		},
+		"Successfully set tls config": func(t *testing.T) test {
+			t.Helper()
+
+			return test{
+				opt: WithTLSConfig(tlsConfig),
+				check: func(t *testing.T, c *Client) {
+					assert.Equal(t, tlsConfig, c.transport.TLSClientConfig)
+				},
+			}
+		},
+		"Failed set tls config": func(t *testing.T) test {
+			t.Helper()
+
+			return test{opt: WithTLSConfig(nil), wantErr: errFailedSetTLSConfig}
+		},
+		"Successfully set proxy": func(t *testing.T) test {
+			t.Helper()
+
+			return test{
+				opt: WithProxy(http.ProxyURL(proxyURL)),
+				check: func(t *testing.T, c *Client) {
+					u, err := c.transport.Proxy(&http.Request{})
+					assert.NoError(t, err)
+					assert.Equal(t, proxyURL, u)
+				},
+			}
+		},
+		"Failed set proxy": func(t *testing.T) test {
+			t.Helper()
+
+			return test{opt: WithProxy(nil), wantErr: errFailedSetProxy}
+		},
+		"Successfully set timeouts": func(t *testing.T) test {
+			t.Helper()
+
+			return test{
+				opt: WithTimeouts(Timeouts{
+					Connect:        time.Second,
+					TLSHandshake:   2 * time.Second,
+					ResponseHeader: 3 * time.Second,
+					Total:          4 * time.Second,
+				}),
+				check: func(t *testing.T, c *Client) {
+					assert.NotNil(t, c.transport.DialContext)
+					assert.Equal(t, 2*time.Second, c.transport.TLSHandshakeTimeout)
+					assert.Equal(t, 3*time.Second, c.transport.ResponseHeaderTimeout)
+					assert.Equal(t, 4*time.Second, c.timeout)
+				},
+			}
+		},
+		"Failed set timeouts": func(t *testing.T) test {
+			t.Helper()
+
+			return test{opt: WithTimeouts(Timeouts{Total: -1}), wantErr: errFailedSetTimeouts}
+		},
+		"Failed set max idle connections": func(t *testing.T) test {
+			t.Helper()
+
+			return test{opt: WithMaxIdleConnsPerHost(0), wantErr: errFailedSetMaxIdleConns}
+		},
+		"Successfully set middleware": func(t *testing.T) test {
+			t.Helper()
+
+			return test{
+				opt: WithMiddleware(BearerAuth("token"), RequestID("", nil)),
+				check: func(t *testing.T, c *Client) {
+					assert.Len(t, c.middlewares, 2)
+				},
+			}
+		},
+		"Failed set middleware": func(t *testing.T) test {
+			t.Helper()
+
+			return test{opt: WithMiddleware(BearerAuth("token"), nil), wantErr: errFailedSetMiddleware}
+		},
+	}
+
+	for name, fn := range tests {
+		t.Run(name, func(t *testing.T) {
+			tt := fn(t)
+
+			c := &Client{transport: &http.Transport{}}
+
+			err := tt.opt(c)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			tt.check(t, c)
+		})
+	}
+}
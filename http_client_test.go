@@ -0,0 +1,85 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockedHTTPClient struct {
+	requests []*http.Request
+	response func() *http.Response
+	err      error
+}
+
+func (m *mockedHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	m.requests = append(m.requests, req)
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	return m.response(), nil
+}
+
+func jsonResponse(statusCode int, body string) func() *http.Response {
+	return func() *http.Response {
+		return &http.Response{
+			StatusCode: statusCode,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	c, err := NewClient(WithMaxIdleConnsPerHost(20))
+	assert.NoError(t, err)
+	assert.Equal(t, 20, c.transport.MaxIdleConnsPerHost)
+	assert.IsType(t, &http.Client{}, c.httpClient)
+
+	_, err = NewClient(WithHTTPClient(nil))
+	assert.ErrorIs(t, err, errInternal)
+
+	rt := &http.Transport{}
+	c, err = NewClient(WithTransport(rt))
+	assert.NoError(t, err)
+	assert.Equal(t, rt, c.httpClient.(*http.Client).Transport)
+}
+
+func TestClientDoRequest(t *testing.T) {
+	mock := &mockedHTTPClient{response: jsonResponse(http.StatusOK, `{"ping": "pong"}`)}
+	c, _ := NewClient(WithHTTPClient(mock))
+
+	rs := &PingModel{}
+	code, err := c.DoRequest(&HttpOptions{
+		Ctx:     context.Background(),
+		Url:     "https://example.com/ping",
+		Method:  http.MethodGet,
+		Queries: map[string]string{"key": "value"},
+	}, rs)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "pong", rs.Ping)
+	assert.Len(t, mock.requests, 1)
+	assert.Equal(t, "https://example.com/ping?key=value", mock.requests[0].URL.String())
+}
+
+func TestClientDoRequestDefaultRetry(t *testing.T) {
+	mock := &mockedHTTPClient{err: errors.New("connection reset")}
+	c, _ := NewClient(WithHTTPClient(mock), WithRetry(&RetryOptions{MaxAttempts: 3, BaseDelay: 1}))
+
+	_, err := c.DoRequest(&HttpOptions{
+		Ctx:    context.Background(),
+		Url:    "https://example.com/ping",
+		Method: http.MethodGet,
+	}, nil)
+
+	assert.Error(t, err)
+	assert.Len(t, mock.requests, 3)
+}
@@ -0,0 +1,120 @@
+package helpers
+
+import (
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"mime"
+	"net/url"
+	"strings"
+)
+
+// Decoder is the interface for decode a response body into a value.
+type Decoder interface {
+	Decode(body []byte, v interface{}) error
+}
+
+// DecoderFunc is an adapter for use a function as a Decoder.
+type DecoderFunc func(body []byte, v interface{}) error
+
+// Decode calls f(body, v).
+func (f DecoderFunc) Decode(body []byte, v interface{}) error {
+	return f(body, v)
+}
+
+var (
+	// JSONDecoder decodes a JSON body.
+	JSONDecoder Decoder = DecoderFunc(json.Unmarshal)
+	// XMLDecoder decodes an XML body.
+	XMLDecoder Decoder = DecoderFunc(xml.Unmarshal)
+	// FormDecoder decodes an url-encoded form body into a *url.Values,
+	// a *map[string][]string or a *map[string]string.
+	FormDecoder Decoder = DecoderFunc(decodeForm)
+	// TextDecoder decodes a text body into a *string, a *[]byte or an encoding.TextUnmarshaler.
+	TextDecoder Decoder = DecoderFunc(decodeText)
+)
+
+// defaultDecoders is the default Decoder per media type.
+var defaultDecoders = map[string]Decoder{
+	"application/json":                  JSONDecoder,
+	"application/xml":                   XMLDecoder,
+	"text/xml":                          XMLDecoder,
+	"application/x-www-form-urlencoded": FormDecoder,
+}
+
+// decoderFor returns the Decoder for the response content type and the target.
+// The raw body is kept for a *[]byte target, the text decoder is used for a
+// *string target without registered decoder and JSON is used by default.
+func (c *Client) decoderFor(opt *HttpOptions, contentType string, rs interface{}) Decoder {
+	if opt.Decoder != nil {
+		return opt.Decoder
+	}
+
+	if _, ok := rs.(*[]byte); ok {
+		return TextDecoder
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if d, ok := c.decoders[mediaType]; ok {
+		return d
+	}
+
+	if d, ok := defaultDecoders[mediaType]; ok {
+		return d
+	}
+
+	switch {
+	case strings.HasSuffix(mediaType, "+json"):
+		return JSONDecoder
+	case strings.HasSuffix(mediaType, "+xml"):
+		return XMLDecoder
+	}
+
+	if _, ok := rs.(*string); ok {
+		return TextDecoder
+	}
+
+	return JSONDecoder
+}
+
+// decodeForm decodes an url-encoded form body.
+func decodeForm(body []byte, v interface{}) error {
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return err
+	}
+
+	switch out := v.(type) {
+	case *url.Values:
+		*out = values
+	case *map[string][]string:
+		*out = values
+	case *map[string]string:
+		m := make(map[string]string, len(values))
+		for key := range values {
+			m[key] = values.Get(key)
+		}
+		*out = m
+	default:
+		return fmt.Errorf("form: cannot decode into %T", v)
+	}
+
+	return nil
+}
+
+// decodeText decodes a text body.
+func decodeText(body []byte, v interface{}) error {
+	switch out := v.(type) {
+	case *string:
+		*out = string(body)
+	case *[]byte:
+		*out = append([]byte{}, body...)
+	case encoding.TextUnmarshaler:
+		return out.UnmarshalText(body)
+	default:
+		return fmt.Errorf("text: cannot decode into %T", v)
+	}
+
+	return nil
+}
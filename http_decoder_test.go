@@ -0,0 +1,117 @@
+package helpers
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func typedResponse(contentType, body string) func() *http.Response {
+	return func() *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {contentType}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+	}
+}
+
+func doMocked(t *testing.T, response func() *http.Response, rs interface{}, opts ...ClientOption) error {
+	t.Helper()
+
+	c, err := NewClient(append([]ClientOption{WithHTTPClient(&mockedHTTPClient{response: response})}, opts...)...)
+	assert.NoError(t, err)
+
+	_, err = c.DoRequest(&HttpOptions{Ctx: context.Background(), Url: "https://example.com", Method: http.MethodGet}, rs)
+
+	return err
+}
+
+func TestDoRequestDecodeXML(t *testing.T) {
+	type Ping struct {
+		Ping string `xml:"ping"`
+	}
+
+	rs := &Ping{}
+	err := doMocked(t, typedResponse("application/xml; charset=utf-8", "<Ping><ping>pong</ping></Ping>"), rs)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "pong", rs.Ping)
+}
+
+func TestDoRequestDecodeJSONSuffix(t *testing.T) {
+	rs := &PingModel{}
+	err := doMocked(t, typedResponse("application/problem+json", `{"ping": "pong"}`), rs)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "pong", rs.Ping)
+}
+
+func TestDoRequestDecodeForm(t *testing.T) {
+	rs := url.Values{}
+	err := doMocked(t, typedResponse("application/x-www-form-urlencoded", "ping=pong&id=1&id=2"), &rs)
+
+	assert.NoError(t, err)
+	assert.Equal(t, url.Values{"ping": {"pong"}, "id": {"1", "2"}}, rs)
+
+	m := map[string]string{}
+	err = doMocked(t, typedResponse("application/x-www-form-urlencoded", "ping=pong"), &m)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"ping": "pong"}, m)
+}
+
+func TestDoRequestDecodeText(t *testing.T) {
+	var rs string
+	err := doMocked(t, typedResponse("text/plain", "pong"), &rs)
+	assert.NoError(t, err)
+	assert.Equal(t, "pong", rs)
+
+	var raw []byte
+	err = doMocked(t, typedResponse("application/json", `{"ping": "pong"}`), &raw)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"ping": "pong"}`, string(raw))
+}
+
+func TestDoRequestDecodeWriter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := doMocked(t, typedResponse("image/png", "binary"), buf)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "binary", buf.String())
+}
+
+func TestDoRequestDecodeEmptyBody(t *testing.T) {
+	rs := &PingModel{}
+	err := doMocked(t, typedResponse("application/json", ""), rs)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "", rs.Ping)
+}
+
+func TestDoRequestCustomDecoder(t *testing.T) {
+	upper := DecoderFunc(func(body []byte, v interface{}) error {
+		*(v.(*string)) = strings.ToUpper(string(body))
+		return nil
+	})
+
+	var rs string
+	err := doMocked(t, typedResponse("application/vnd.custom", "pong"), &rs, WithDecoder("application/vnd.custom", upper))
+	assert.NoError(t, err)
+	assert.Equal(t, "PONG", rs)
+
+	_, err = NewClient(WithDecoder("", upper))
+	assert.ErrorIs(t, err, errInternal)
+}
+
+func TestDoRequestDecodeError(t *testing.T) {
+	rs := 0
+	err := doMocked(t, typedResponse("application/x-www-form-urlencoded", "ping=pong"), &rs)
+
+	assert.EqualError(t, err, "form: cannot decode into *int")
+}
@@ -0,0 +1,96 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// HTTPError represent the error for a response with a 4xx or 5xx status code
+type HTTPError struct {
+	StatusCode int
+	Status     string
+	Header     http.Header
+	// Body is the raw response body.
+	Body []byte
+}
+
+// Error returns the error message e.g. "http error: 404 Not Found".
+func (e *HTTPError) Error() string {
+	status := e.Status
+	if status == "" {
+		status = fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+
+	return fmt.Sprintf("http error: %s", status)
+}
+
+// TransportError represent the error for a request which failed before a
+// response was received e.g. a connection reset.
+type TransportError struct {
+	Err error
+}
+
+// Error returns the message of the underlying error.
+func (e *TransportError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// TimeoutError represent the error for a request which timed out.
+type TimeoutError struct {
+	Err error
+}
+
+// Error returns the message of the underlying error.
+func (e *TimeoutError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// Timeout reports whether the error is a timeout, always true.
+func (e *TimeoutError) Timeout() bool {
+	return true
+}
+
+// DecodeError represent the error for a response body which could not be decoded.
+type DecodeError struct {
+	ContentType string
+	Err         error
+}
+
+// Error returns the message of the underlying error.
+func (e *DecodeError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// transportError classifies the error of a failed request into a *TimeoutError
+// or a *TransportError. A *CircuitOpenError is returned as-is.
+func transportError(err error) error {
+	var circuitErr *CircuitOpenError
+	if errors.As(err, &circuitErr) {
+		return circuitErr
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return &TimeoutError{Err: err}
+	}
+
+	return &TransportError{Err: err}
+}
@@ -0,0 +1,81 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ErrorModel struct {
+	Message string `json:"message"`
+}
+
+func TestDoRequestHTTPError(t *testing.T) {
+	srv := HttpMock("/users", http.StatusNotFound, `{"message": "user not found"}`)
+	defer srv.Close()
+
+	rs := &PingModel{}
+	errRs := &ErrorModel{}
+	code, err := DoRequest(&HttpOptions{
+		Ctx:         context.Background(),
+		Url:         srv.URL + "/users",
+		Method:      http.MethodGet,
+		ErrorTarget: errRs,
+	}, rs)
+
+	var httpErr *HTTPError
+	assert.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Equal(t, http.StatusNotFound, httpErr.StatusCode)
+	assert.Equal(t, `{"message": "user not found"}`, string(httpErr.Body))
+	assert.Equal(t, "http error: 404 Not Found", err.Error())
+	assert.Equal(t, "user not found", errRs.Message)
+	assert.Equal(t, "", rs.Ping)
+}
+
+func TestDoRequestTransportError(t *testing.T) {
+	mock := &mockedHTTPClient{err: errors.New("connection reset")}
+	c, _ := NewClient(WithHTTPClient(mock))
+
+	_, err := c.DoRequest(&HttpOptions{Ctx: context.Background(), Url: "https://example.com", Method: http.MethodGet}, nil)
+
+	var transportErr *TransportError
+	assert.True(t, errors.As(err, &transportErr))
+	assert.Equal(t, "connection reset", err.Error())
+}
+
+func TestDoRequestTimeoutError(t *testing.T) {
+	srv := HttpMock("/ping", http.StatusOK, PingModel{"pong"})
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), -time.Second)
+	defer cancel()
+
+	_, err := DoRequest(&HttpOptions{Ctx: ctx, Url: srv.URL + "/ping", Method: http.MethodGet}, nil)
+
+	var timeoutErr *TimeoutError
+	assert.True(t, errors.As(err, &timeoutErr))
+	assert.True(t, timeoutErr.Timeout())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestDoRequestDecodeErrorType(t *testing.T) {
+	srv := HttpMock("/ping", http.StatusOK, `not json`)
+	defer srv.Close()
+
+	_, err := DoRequest(&HttpOptions{Ctx: context.Background(), Url: srv.URL + "/ping", Method: http.MethodGet}, &PingModel{})
+
+	var decodeErr *DecodeError
+	assert.True(t, errors.As(err, &decodeErr))
+	assert.Equal(t, "text/plain; charset=utf-8", decodeErr.ContentType)
+}
+
+func TestHTTPErrorMessage(t *testing.T) {
+	err := &HTTPError{StatusCode: http.StatusServiceUnavailable}
+
+	assert.Equal(t, "http error: 503 Service Unavailable", err.Error())
+}
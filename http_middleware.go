@@ -0,0 +1,120 @@
+package helpers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// DefaultRequestIDHeader is the default header of the request ID.
+const DefaultRequestIDHeader = "X-Request-ID"
+
+// RoundTripperFunc is an adapter to allow the use of ordinary functions as http.RoundTripper.
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req).
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware intercepts the requests sent by the Client, it wraps the next
+// round tripper of the chain. A middleware must not modify the given request,
+// it sends a clone instead.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// Chain returns the round tripper wrapped by the middlewares, the first
+// middleware is the outermost one and sees the request first.
+func Chain(rt http.RoundTripper, middlewares ...Middleware) http.RoundTripper {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		rt = middlewares[i](rt)
+	}
+
+	return rt
+}
+
+// setHeader returns the middleware that set the header value returned by value
+// on a clone of the request, the request is sent as-is when value is empty.
+func setHeader(name string, value func(req *http.Request) string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			v := value(req)
+			if v == "" {
+				return next.RoundTrip(req)
+			}
+
+			req = req.Clone(req.Context())
+			req.Header.Set(name, v)
+
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// BearerAuth returns the middleware that set the Authorization header with the bearer token.
+func BearerAuth(token string) Middleware {
+	return setHeader("Authorization", func(*http.Request) string {
+		return "Bearer " + token
+	})
+}
+
+// BasicAuth returns the middleware that set the Authorization header with the basic credentials.
+func BasicAuth(username, password string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			req.SetBasicAuth(username, password)
+
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// requestIDKey is the context key of the request ID.
+type requestIDKey struct{}
+
+// ContextWithRequestID returns a copy of the context carrying the request ID.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID carried by the context.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+
+	return id, ok && id != ""
+}
+
+// RequestID returns the middleware that propagates the request ID of the
+// request context in the header, X-Request-ID when empty. Without request ID
+// in the context, a new one is made by generate, a random hex ID when nil. The
+// header already set on the request is kept.
+func RequestID(header string, generate func() string) Middleware {
+	if header == "" {
+		header = DefaultRequestIDHeader
+	}
+
+	if generate == nil {
+		generate = newRequestID
+	}
+
+	return setHeader(header, func(req *http.Request) string {
+		if req.Header.Get(header) != "" {
+			return ""
+		}
+
+		if id, ok := RequestIDFromContext(req.Context()); ok {
+			return id
+		}
+
+		return generate()
+	})
+}
+
+// newRequestID returns a random 128-bit request ID in hex.
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
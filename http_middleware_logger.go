@@ -0,0 +1,97 @@
+//go:build go1.21
+
+package helpers
+
+import (
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// redactedValue replaces the values of the secrets in the logs.
+const redactedValue = "[REDACTED]"
+
+// defaultRedactedHeaders is the list of the headers always redacted in the logs.
+var defaultRedactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// Logger returns the middleware that logs every request with the logger,
+// slog.Default() when nil. The request is logged with its method, url, request
+// headers, response status and duration at the info level, or with its error
+// at the error level. The Authorization, Proxy-Authorization, Cookie and
+// Set-Cookie headers, and the headers and query parameters named in redact
+// are logged as "[REDACTED]". Logger requires Go 1.21 for log/slog.
+func Logger(logger *slog.Logger, redact ...string) Middleware {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	redacted := make(map[string]bool)
+	for _, name := range append(append([]string{}, defaultRedactedHeaders...), redact...) {
+		redacted[strings.ToLower(name)] = true
+	}
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+
+			attrs := []slog.Attr{
+				slog.String("method", req.Method),
+				slog.String("url", redactURL(req.URL, redacted)),
+				slog.Any("headers", redactHeader(req.Header, redacted)),
+				slog.Duration("duration", time.Since(start)),
+			}
+			if id, ok := RequestIDFromContext(req.Context()); ok {
+				attrs = append(attrs, slog.String("request_id", id))
+			}
+
+			if err != nil {
+				attrs = append(attrs, slog.String("error", err.Error()))
+				logger.LogAttrs(req.Context(), slog.LevelError, "http request failed", attrs...)
+
+				return resp, err
+			}
+
+			attrs = append(attrs, slog.Int("status", resp.StatusCode))
+			logger.LogAttrs(req.Context(), slog.LevelInfo, "http request", attrs...)
+
+			return resp, nil
+		})
+	}
+}
+
+// redactURL returns the url with the redacted query parameters and user password.
+func redactURL(u *url.URL, redacted map[string]bool) string {
+	clone := *u
+	query := clone.Query()
+	changed := false
+	for key, values := range query {
+		if redacted[strings.ToLower(key)] {
+			for i := range values {
+				values[i] = redactedValue
+			}
+			changed = true
+		}
+	}
+	if changed {
+		clone.RawQuery = query.Encode()
+	}
+
+	return clone.Redacted()
+}
+
+// redactHeader returns a copy of the header with the redacted values.
+func redactHeader(header http.Header, redacted map[string]bool) http.Header {
+	clone := header.Clone()
+	for key, values := range clone {
+		if redacted[strings.ToLower(key)] {
+			for i := range values {
+				values[i] = redactedValue
+			}
+		}
+	}
+
+	return clone
+}
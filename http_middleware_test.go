@@ -0,0 +1,123 @@
+package helpers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChainOrder(t *testing.T) {
+	calls := []string{}
+	trace := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	rt := Chain(RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		calls = append(calls, "transport")
+		return jsonResponse(http.StatusOK, `{}`)(), nil
+	}), trace("first"), trace("second"))
+
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost", nil)
+	_, err := rt.RoundTrip(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"first", "second", "transport"}, calls)
+}
+
+func TestClientMiddleware(t *testing.T) {
+	mock := &mockedHTTPClient{response: jsonResponse(http.StatusOK, `{"ping": "pong"}`)}
+	c, err := NewClient(
+		WithHTTPClient(mock),
+		WithMiddleware(BearerAuth("secret"), RequestID("", func() string { return "generated" })),
+	)
+	assert.NoError(t, err)
+
+	rs := &PingModel{}
+	_, err = c.DoRequest(&HttpOptions{Url: "http://localhost/ping", Method: http.MethodGet}, rs)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "pong", rs.Ping)
+	assert.Len(t, mock.requests, 1)
+	assert.Equal(t, "Bearer secret", mock.requests[0].Header.Get("Authorization"))
+	assert.Equal(t, "generated", mock.requests[0].Header.Get(DefaultRequestIDHeader))
+}
+
+func TestBasicAuth(t *testing.T) {
+	var got *http.Request
+	rt := BasicAuth("user", "pass")(RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		got = req
+		return jsonResponse(http.StatusOK, `{}`)(), nil
+	}))
+
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost", nil)
+	_, err := rt.RoundTrip(req)
+
+	assert.NoError(t, err)
+	user, pass, ok := got.BasicAuth()
+	assert.True(t, ok)
+	assert.Equal(t, "user", user)
+	assert.Equal(t, "pass", pass)
+	assert.Empty(t, req.Header.Get("Authorization"))
+}
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		header   string
+		expected string
+	}{
+		{
+			name:     "from context",
+			ctx:      ContextWithRequestID(context.Background(), "ctx-id"),
+			expected: "ctx-id",
+		},
+		{
+			name:     "generated",
+			ctx:      context.Background(),
+			expected: "generated",
+		},
+		{
+			name:     "already set",
+			ctx:      ContextWithRequestID(context.Background(), "ctx-id"),
+			header:   "header-id",
+			expected: "header-id",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			rt := RequestID("X-Correlation-ID", func() string { return "generated" })(
+				RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					got = req.Header.Get("X-Correlation-ID")
+					return jsonResponse(http.StatusOK, `{}`)(), nil
+				}),
+			)
+
+			req, _ := http.NewRequestWithContext(tt.ctx, http.MethodGet, "http://localhost", nil)
+			if tt.header != "" {
+				req.Header.Set("X-Correlation-ID", tt.header)
+			}
+
+			_, err := rt.RoundTrip(req)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestNewRequestID(t *testing.T) {
+	id := newRequestID()
+
+	assert.Len(t, id, 32)
+	assert.NotEqual(t, id, newRequestID())
+}
@@ -0,0 +1,260 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is an error message when a request cannot be sent within the rate limit.
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// RateLimitError represent the error for a request which would wait for the
+// rate limit beyond the deadline of its context
+type RateLimitError struct {
+	// Host is the host of the request, empty for a limit per client.
+	Host string
+	// Wait is the time the request would wait for the rate limit.
+	Wait time.Duration
+}
+
+// Error returns the error message e.g. "rate limit exceeded: would wait 1.5s".
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%s: would wait %s", ErrRateLimited, e.Wait)
+}
+
+// Is reports whether the target is ErrRateLimited.
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// RateLimiterOptions represent the configuration of a RateLimiter
+type RateLimiterOptions struct {
+	// Rate is the number of requests allowed per second, the requests are only
+	// delayed by the 429 responses when zero: one request is sent per
+	// Retry-After window until a whole window goes by without a request.
+	Rate float64
+	// Burst is the maximum number of requests sent at once. 1 when zero.
+	Burst int
+	// PerHost enables a limit for every host instead of one limit for all the requests.
+	PerHost bool
+}
+
+// bucket is the token bucket of a host.
+type bucket struct {
+	tokens float64
+	// last is the time of the last refill, in the future while paused by a 429 response.
+	last time.Time
+	// window is the Retry-After of the last 429 response while it paces the
+	// requests of a limiter without Rate, zero otherwise.
+	window time.Duration
+}
+
+// RateLimiter represent the token bucket rate limiter of the outbound
+// requests, safe for concurrent use. A request waits for a token, it fails
+// with a *RateLimitError when the wait would exceed the deadline of its
+// context. A 429 response pauses the requests until its Retry-After.
+//
+// The limiter is plugged into a Client with WithMiddleware(l.Middleware()),
+// or wraps any HTTPClient with l.Client, e.g. the HTTPClient of tinify,
+// kraken, resmush or diskstorage:
+//
+//	tinify.WithHTTPClient(l.Client(http.DefaultClient))
+type RateLimiter struct {
+	opts    RateLimiterOptions
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+// NewRateLimiter returns a new RateLimiter with the given options.
+func NewRateLimiter(opts RateLimiterOptions) *RateLimiter {
+	if opts.Burst <= 0 {
+		opts.Burst = 1
+	}
+
+	return &RateLimiter{opts: opts, buckets: make(map[string]*bucket), now: time.Now}
+}
+
+// Wait blocks until a request to the host is allowed. It returns a
+// *RateLimitError without waiting when the deadline of the context is too
+// short, or the error of the context when it is done while waiting.
+func (l *RateLimiter) Wait(ctx context.Context, host string) error {
+	key := l.key(host)
+
+	l.mu.Lock()
+	now := l.now()
+	wait := l.reserve(key, now)
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && now.Add(wait).After(deadline) {
+		l.cancel(key)
+		return &RateLimitError{Host: key, Wait: wait}
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		l.cancel(key)
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Middleware returns the middleware that waits for the rate limit before
+// sending a request and adapts the limit to the 429 responses.
+func (l *RateLimiter) Middleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return l.do(req, next.RoundTrip)
+		})
+	}
+}
+
+// Client returns the HTTPClient sending the requests with the given client
+// within the rate limit.
+func (l *RateLimiter) Client(c HTTPClient) HTTPClient {
+	return &rateLimitedClient{limiter: l, client: c}
+}
+
+// rateLimitedClient is the HTTPClient returned by RateLimiter.Client.
+type rateLimitedClient struct {
+	limiter *RateLimiter
+	client  HTTPClient
+}
+
+// Do sends the request within the rate limit.
+func (c *rateLimitedClient) Do(req *http.Request) (*http.Response, error) {
+	return c.limiter.do(req, c.client.Do)
+}
+
+// do waits for the rate limit, sends the request and observes its response.
+// The body of a request which is not sent is closed, like http.Client.Do.
+func (l *RateLimiter) do(
+	req *http.Request, send func(*http.Request) (*http.Response, error),
+) (*http.Response, error) {
+	if err := l.Wait(req.Context(), req.URL.Host); err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+
+	resp, err := send(req)
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		l.pause(req.URL.Host, resp.Header.Get("Retry-After"))
+	}
+
+	return resp, err
+}
+
+// key returns the bucket key of the host, empty for a limit per client.
+func (l *RateLimiter) key(host string) string {
+	if !l.opts.PerHost {
+		return ""
+	}
+
+	return host
+}
+
+// bucket returns the bucket of the key, a full one when new. The lock must be held.
+func (l *RateLimiter) bucket(key string, now time.Time) *bucket {
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.opts.Burst), last: now}
+		l.buckets[key] = b
+	}
+
+	return b
+}
+
+// reserve takes a token of the bucket and returns the time to wait for it.
+// The lock must be held.
+func (l *RateLimiter) reserve(key string, now time.Time) time.Duration {
+	b := l.bucket(key, now)
+	rate, burst := l.rate(b)
+	if now.After(b.last) {
+		tokens := b.tokens + now.Sub(b.last).Seconds()*rate
+		if b.window > 0 && tokens >= 1 && now.Sub(b.last) >= b.window {
+			// a whole window went by without a request, the pacing is over.
+			b.window = 0
+			rate, burst = l.rate(b)
+		}
+
+		b.tokens = math.Min(burst, tokens)
+		b.last = now
+	}
+
+	wait := b.last.Sub(now)
+	if rate <= 0 {
+		return wait
+	}
+
+	b.tokens--
+	if b.tokens < 0 {
+		wait += time.Duration(-b.tokens / rate * float64(time.Second))
+	}
+
+	return wait
+}
+
+// rate returns the refill rate per second and the burst of the bucket, one
+// request per window while a limiter without Rate is paced. The lock must be held.
+func (l *RateLimiter) rate(b *bucket) (float64, float64) {
+	if l.opts.Rate <= 0 && b.window > 0 {
+		return 1 / b.window.Seconds(), 1
+	}
+
+	return l.opts.Rate, float64(l.opts.Burst)
+}
+
+// cancel gives back the token of a request which is not sent.
+func (l *RateLimiter) cancel(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if b, ok := l.buckets[key]; ok {
+		if rate, burst := l.rate(b); rate > 0 {
+			b.tokens = math.Min(burst, b.tokens+1)
+		}
+	}
+}
+
+// pause pauses the requests to the host after a 429 response until its
+// Retry-After, a single request is sent then. Without Retry-After, the bucket
+// is emptied. A limiter without Rate is paced by the Retry-After window.
+func (l *RateLimiter) pause(host, retryAfter string) {
+	key := l.key(host)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b := l.bucket(key, now)
+
+	delay, ok := parseRetryAfter(retryAfter, now)
+	if !ok {
+		b.tokens = math.Min(b.tokens, 0)
+		return
+	}
+
+	b.tokens = math.Min(b.tokens, 1)
+	if until := now.Add(delay); until.After(b.last) {
+		b.last = until
+	}
+
+	if l.opts.Rate <= 0 && delay > 0 {
+		b.window = delay
+	}
+}
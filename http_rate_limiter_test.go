@@ -0,0 +1,173 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterReserve(t *testing.T) {
+	l := NewRateLimiter(RateLimiterOptions{Rate: 10, Burst: 2})
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Duration(0), l.reserve("", now))
+	assert.Equal(t, time.Duration(0), l.reserve("", now))
+	assert.Equal(t, 100*time.Millisecond, l.reserve("", now))
+	assert.Equal(t, 200*time.Millisecond, l.reserve("", now))
+
+	// the bucket is refilled up to the burst.
+	now = now.Add(time.Hour)
+	assert.Equal(t, time.Duration(0), l.reserve("", now))
+	assert.Equal(t, time.Duration(0), l.reserve("", now))
+	assert.Equal(t, 100*time.Millisecond, l.reserve("", now))
+}
+
+func TestRateLimiterPerHost(t *testing.T) {
+	l := NewRateLimiter(RateLimiterOptions{Rate: 1, PerHost: true})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	assert.NoError(t, l.Wait(ctx, "a.example.com"))
+	assert.NoError(t, l.Wait(ctx, "b.example.com"))
+
+	err := l.Wait(ctx, "a.example.com")
+	rateErr := &RateLimitError{}
+	assert.True(t, errors.As(err, &rateErr))
+	assert.True(t, errors.Is(err, ErrRateLimited))
+	assert.Equal(t, "a.example.com", rateErr.Host)
+
+	// the token of the failed request is given back.
+	assert.InDelta(t, 0, l.buckets["a.example.com"].tokens, 0.1)
+}
+
+func TestRateLimiterWaitContextCanceled(t *testing.T) {
+	l := NewRateLimiter(RateLimiterOptions{Rate: 1})
+	assert.NoError(t, l.Wait(context.Background(), ""))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	err := l.Wait(ctx, "")
+
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestRateLimiterWaitBlocks(t *testing.T) {
+	l := NewRateLimiter(RateLimiterOptions{Rate: 50})
+	start := time.Now()
+
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, l.Wait(context.Background(), ""))
+	}
+
+	assert.True(t, time.Since(start) >= 35*time.Millisecond)
+}
+
+func TestRateLimiterPause(t *testing.T) {
+	l := NewRateLimiter(RateLimiterOptions{Rate: 100, Burst: 5})
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+
+	l.pause("", "2")
+
+	assert.Equal(t, 2*time.Second, l.reserve("", now))
+	assert.Equal(t, 2*time.Second+10*time.Millisecond, l.reserve("", now))
+
+	l.pause("", "")
+
+	assert.Equal(t, 2*time.Second+20*time.Millisecond, l.reserve("", now))
+}
+
+func TestRateLimiterPauseWithoutRate(t *testing.T) {
+	l := NewRateLimiter(RateLimiterOptions{})
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+
+	assert.Equal(t, time.Duration(0), l.reserve("", now))
+	assert.Equal(t, time.Duration(0), l.reserve("", now))
+
+	l.pause("", "2")
+
+	// the concurrent waiters are released one per Retry-After window.
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		waits []time.Duration
+	)
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			l.mu.Lock()
+			wait := l.reserve("", now)
+			l.mu.Unlock()
+
+			mu.Lock()
+			waits = append(waits, wait)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	sort.Slice(waits, func(i, j int) bool { return waits[i] < waits[j] })
+	assert.Equal(t, []time.Duration{2 * time.Second, 4 * time.Second, 6 * time.Second}, waits)
+
+	// the pacing is over after a whole window without a request.
+	now = now.Add(8 * time.Second)
+	assert.Equal(t, time.Duration(0), l.reserve("", now))
+	assert.Equal(t, time.Duration(0), l.reserve("", now))
+}
+
+func TestRateLimiterClient(t *testing.T) {
+	calls := 0
+	mock := &mockedHTTPClient{response: func() *http.Response {
+		calls++
+		resp := jsonResponse(http.StatusTooManyRequests, `{}`)()
+		resp.Header.Set("Retry-After", "3600")
+		return resp
+	}}
+	l := NewRateLimiter(RateLimiterOptions{})
+	client := l.Client(mock)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://api.example.com", nil)
+
+	resp, err := client.Do(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+	body := &closerBody{}
+	req, _ = http.NewRequestWithContext(ctx, http.MethodPost, "http://api.example.com", body)
+	_, err = client.Do(req)
+	assert.True(t, errors.Is(err, ErrRateLimited))
+	assert.True(t, body.closed)
+	assert.Equal(t, 1, calls)
+}
+
+func TestClientRateLimiterMiddleware(t *testing.T) {
+	mock := &mockedHTTPClient{response: jsonResponse(http.StatusOK, `{}`)}
+	l := NewRateLimiter(RateLimiterOptions{Rate: 0.001})
+	c, err := NewClient(
+		WithHTTPClient(mock),
+		WithMiddleware(l.Middleware()),
+		WithRetry(&RetryOptions{MaxAttempts: 3}),
+	)
+	assert.NoError(t, err)
+
+	opt := &HttpOptions{Url: "http://api.example.com", Method: http.MethodGet, Timeout: time.Second}
+	_, err = c.DoRequest(opt, nil)
+	assert.NoError(t, err)
+
+	_, err = c.DoRequest(opt, nil)
+	rateErr := &RateLimitError{}
+	assert.True(t, errors.As(err, &rateErr))
+	assert.Len(t, mock.requests, 1)
+}
@@ -3,67 +3,190 @@ package helpers
 import (
 	"bytes"
 	"context"
-	"encoding/json"
-	"io/ioutil"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
 	"time"
 )
 
+// pathParam matches a path parameter of the url e.g. "{id}".
+var pathParam = regexp.MustCompile(`\{([^{}/]+)\}`)
+
 type HttpOptions struct {
-	Ctx     context.Context
-	Url     string
-	TO      *time.Duration
+	// Ctx is the context of the request, context.Background() when nil.
+	Ctx context.Context
+	// BaseURL is joined with Url when Url is a relative path e.g. "/users/{id}".
+	BaseURL string
+	// Url is the url of the request, a relative path when BaseURL is set.
+	Url string
+	// PathParams is the values of the path parameters of the Url path such as
+	// "{id}", replaced by the escaped values. Url is not templated when nil.
+	PathParams map[string]string
+	// TO is the timeout of the request.
+	//
+	// Deprecated: TO is a number of seconds, time.Duration(10) is 10 seconds.
+	// A value of at least one millisecond is used as a real duration for the
+	// callers passing e.g. 5*time.Second or 500*time.Millisecond. Use Timeout instead.
+	TO *time.Duration
+	// Timeout is the total timeout of the request including the retries, it
+	// overrides the Timeouts.Total of the client.
+	Timeout time.Duration
+	// Headers is the headers of the request, replacing the values set before.
 	Headers map[string]string
+	// Header is the multi-value headers of the request, appended to the other values.
+	Header http.Header
+	// Queries is the query parameters of the request, replacing the values
+	// already present in Url.
 	Queries map[string]string
-	Data    []byte
-	Method  string
+	// QueryValues is the multi-value query parameters of the request, appended
+	// to the values already present in Url e.g. "?id=1&id=2".
+	QueryValues url.Values
+	Data        []byte
+	// Body is the body of the request, it overrides Data. Its Content-Type is
+	// set unless the Headers already have one.
+	Body   RequestBody
+	Method string
+	// Retry is the retry configuration, the request is sent once when nil.
+	Retry *RetryOptions
+	// Decoder is the decoder of the response body, chosen by the response
+	// content type when nil.
+	Decoder Decoder
+	// ErrorTarget is the value the body of a 4xx or 5xx response is decoded into.
+	ErrorTarget interface{}
+	// KeepRawBody keeps the raw response body in Response.Body e.g. for logging.
+	KeepRawBody bool
 }
 
-func DoRequest(opt *HttpOptions, rs interface{}) (int, error) {
-	if opt.TO != nil {
-		timeout := *opt.TO
-		ctx, cancel := context.WithTimeout(opt.Ctx, timeout*time.Second)
-		defer cancel()
+// context returns the context of the request with its total timeout, the
+// Timeout of the options, the deprecated TO or the given default timeout.
+func (opt *HttpOptions) context(defaultTimeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx := opt.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	timeout := defaultTimeout
+	switch {
+	case opt.Timeout > 0:
+		timeout = opt.Timeout
+	case opt.TO != nil:
+		timeout = legacyTimeout(*opt.TO)
+	}
 
-		opt.Ctx = ctx
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+// legacyTimeout converts the deprecated TO value into a duration. A value
+// below one millisecond is a number of seconds, the other values are a real
+// duration e.g. 500*time.Millisecond.
+func legacyTimeout(to time.Duration) time.Duration {
+	if to > 0 && to < time.Millisecond {
+		return to * time.Second
+	}
+
+	return to
+}
+
+// newRequest builds the http request of the options, the Data body is replayed
+// on every call. The Body reader is closed when the request cannot be built.
+func (opt *HttpOptions) newRequest(ctx context.Context) (*http.Request, error) {
+	rawURL, err := opt.url()
+	if err != nil {
+		return nil, err
 	}
 
-	body := bytes.NewBuffer(opt.Data)
-	defer body.Reset()
+	var body io.Reader = bytes.NewReader(opt.Data)
+	if opt.Body != nil {
+		body, err = opt.Body.Reader()
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	req, err := http.NewRequestWithContext(opt.Ctx, opt.Method, opt.Url, body)
+	req, err := http.NewRequestWithContext(ctx, opt.Method, rawURL, body)
 	if err != nil {
-		return http.StatusInternalServerError, err
+		if closer, ok := body.(io.Closer); ok {
+			closer.Close()
+		}
+		return nil, err
+	}
+	if opt.Body != nil && opt.Body.ContentType() != "" {
+		req.Header.Set("Content-Type", opt.Body.ContentType())
 	}
 	for k, v := range opt.Headers {
 		req.Header.Set(k, v)
 	}
+	for k, values := range opt.Header {
+		for _, v := range values {
+			req.Header.Add(k, v)
+		}
+	}
 
-	queryValues := req.URL.Query()
-	for key, val := range opt.Queries {
-		queryValues.Set(key, val)
+	if len(opt.Queries) > 0 || len(opt.QueryValues) > 0 {
+		queryValues := req.URL.Query()
+		for key, val := range opt.Queries {
+			queryValues.Set(key, val)
+		}
+		for key, values := range opt.QueryValues {
+			for _, val := range values {
+				queryValues.Add(key, val)
+			}
+		}
+		req.URL.RawQuery = queryValues.Encode()
 	}
-	req.URL.RawQuery = queryValues.Encode()
 
-	httpClient := &http.Client{}
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		return http.StatusInternalServerError, err
+	return req, nil
+}
+
+// url returns the url of the request, Url joined with BaseURL when relative.
+// When PathParams is set, the path parameters of the Url path are replaced by
+// the escaped PathParams, the query and the fragment are kept as-is.
+func (opt *HttpOptions) url() (string, error) {
+	path, rest := opt.Url, ""
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path, rest = path[:i], path[i:]
 	}
-	defer resp.Body.Close()
 
-	if rs == nil {
-		return resp.StatusCode, nil
+	if opt.PathParams != nil {
+		var missing []string
+		path = pathParam.ReplaceAllStringFunc(path, func(param string) string {
+			name := param[1 : len(param)-1]
+			value, ok := opt.PathParams[name]
+			if !ok {
+				missing = append(missing, name)
+				return param
+			}
+
+			return url.PathEscape(value)
+		})
+		if len(missing) > 0 {
+			return "", fmt.Errorf("missing path parameter(s) %s", strings.Join(missing, ", "))
+		}
 	}
 
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return http.StatusInternalServerError, err
+	if opt.BaseURL == "" {
+		return path + rest, nil
 	}
 
-	err = json.Unmarshal(respBody, rs)
+	u, err := url.Parse(path)
 	if err != nil {
-		return http.StatusInternalServerError, err
+		return "", err
+	}
+
+	if u.IsAbs() {
+		return path + rest, nil
 	}
-	return resp.StatusCode, nil
+
+	if path == "" {
+		return opt.BaseURL + rest, nil
+	}
+
+	return strings.TrimRight(opt.BaseURL, "/") + "/" + strings.TrimLeft(path, "/") + rest, nil
 }
@@ -2,9 +2,15 @@ package helpers
 
 import (
 	"context"
+	"errors"
+	"io"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestDoRequestWithoutResp(t *testing.T) {
@@ -34,7 +40,7 @@ func TestDoRequestWithoutResp(t *testing.T) {
 	}
 }
 
-func TestDoRequestErrCtx(t *testing.T) {
+func TestDoRequestNilCtx(t *testing.T) {
 	ping := PingModel{"pong"}
 	srv := HttpMock("/ping", http.StatusOK, ping)
 	defer srv.Close()
@@ -48,9 +54,8 @@ func TestDoRequestErrCtx(t *testing.T) {
 
 	_, err := DoRequest(opt, nil)
 
-	expt := "net/http: nil Context"
-	if err.Error() != expt {
-		t.Error("expected", expt, "got", err.Error())
+	if err != nil {
+		t.Error("expected", nil, "got", err.Error())
 	}
 }
 
@@ -90,3 +95,253 @@ func TestDoRequestErrEmptyURL(t *testing.T) {
 		t.Error("expected", expt, "got", err.Error())
 	}
 }
+
+func TestDoRequestTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	opt := &HttpOptions{
+		Ctx:     context.Background(),
+		Url:     srv.URL,
+		Timeout: 20 * time.Millisecond,
+		Method:  http.MethodGet,
+	}
+
+	_, err := DoRequest(opt, nil)
+
+	var timeoutErr *TimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Error("expected", "*TimeoutError", "got", err)
+	}
+}
+
+func TestDoRequestSubSecondTO(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	to := 20 * time.Millisecond
+	opt := &HttpOptions{Url: srv.URL, TO: &to, Method: http.MethodGet}
+
+	start := time.Now()
+	_, err := DoRequest(opt, nil)
+
+	var timeoutErr *TimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Error("expected", "*TimeoutError", "got", err)
+	}
+
+	if elapsed := time.Since(start); elapsed >= time.Second {
+		t.Error("expected", "< 1s", "got", elapsed)
+	}
+}
+
+func TestDoRequestClientTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer srv.Close()
+
+	c, _ := NewClient(WithTimeouts(Timeouts{ResponseHeader: 20 * time.Millisecond}))
+	_, err := c.DoRequest(&HttpOptions{Url: srv.URL, Method: http.MethodGet}, nil)
+
+	var timeoutErr *TimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Error("expected", "*TimeoutError", "got", err)
+	}
+}
+
+func TestLegacyTimeout(t *testing.T) {
+	if got := legacyTimeout(time.Duration(10)); got != 10*time.Second {
+		t.Error("expected", 10*time.Second, "got", got)
+	}
+
+	if got := legacyTimeout(5 * time.Second); got != 5*time.Second {
+		t.Error("expected", 5*time.Second, "got", got)
+	}
+
+	if got := legacyTimeout(500 * time.Millisecond); got != 500*time.Millisecond {
+		t.Error("expected", 500*time.Millisecond, "got", got)
+	}
+
+	if got := legacyTimeout(3600); got != time.Hour {
+		t.Error("expected", time.Hour, "got", got)
+	}
+
+	if got := legacyTimeout(7200); got != 2*time.Hour {
+		t.Error("expected", 2*time.Hour, "got", got)
+	}
+
+	if got := legacyTimeout(time.Millisecond); got != time.Millisecond {
+		t.Error("expected", time.Millisecond, "got", got)
+	}
+}
+
+func TestNewRequestMultiValues(t *testing.T) {
+	opt := &HttpOptions{
+		Url:         "http://localhost/users?id=1&key=old",
+		Headers:     map[string]string{"Accept": "text/plain"},
+		Header:      http.Header{"Accept": {"application/json", "application/xml"}},
+		Queries:     map[string]string{"key": "value"},
+		QueryValues: url.Values{"id": {"2", "3"}},
+		Method:      http.MethodGet,
+	}
+
+	req, err := opt.newRequest(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"text/plain", "application/json", "application/xml"}, req.Header.Values("Accept"))
+	assert.Equal(t, url.Values{"id": {"1", "2", "3"}, "key": {"value"}}, req.URL.Query())
+}
+
+func TestNewRequestKeepsURLQuery(t *testing.T) {
+	opt := &HttpOptions{Url: "http://localhost/users?b=2&a=1", Method: http.MethodGet}
+
+	req, err := opt.newRequest(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "b=2&a=1", req.URL.RawQuery)
+}
+
+// closerBody is a RequestBody which records whether its reader was closed.
+type closerBody struct {
+	closed bool
+}
+
+func (b *closerBody) ContentType() string { return "text/plain" }
+
+func (b *closerBody) Reader() (io.Reader, error) { return b, nil }
+
+func (b *closerBody) Read(p []byte) (int, error) { return 0, io.EOF }
+
+func (b *closerBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestNewRequestClosesBody(t *testing.T) {
+	body := MultipartBody(map[string]string{"name": "pong"})
+	opt := &HttpOptions{Url: "/users/{id}", PathParams: map[string]string{}, Method: http.MethodPost, Body: body}
+
+	_, err := opt.newRequest(context.Background())
+	assert.Error(t, err)
+
+	_, err = body.Reader()
+	assert.NoError(t, err)
+
+	closer := &closerBody{}
+	opt = &HttpOptions{Url: "http://localhost/users", Method: "bad method", Body: closer}
+
+	_, err = opt.newRequest(context.Background())
+	assert.Error(t, err)
+	assert.True(t, closer.closed)
+}
+
+func TestHttpOptionsURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		opt      HttpOptions
+		expected string
+		err      string
+	}{
+		{
+			name:     "absolute url",
+			opt:      HttpOptions{Url: "http://localhost/users"},
+			expected: "http://localhost/users",
+		},
+		{
+			name:     "base url and relative path",
+			opt:      HttpOptions{BaseURL: "http://localhost/v1/", Url: "/users"},
+			expected: "http://localhost/v1/users",
+		},
+		{
+			name:     "base url and absolute url",
+			opt:      HttpOptions{BaseURL: "http://localhost/v1", Url: "https://example.com/users"},
+			expected: "https://example.com/users",
+		},
+		{
+			name:     "base url only",
+			opt:      HttpOptions{BaseURL: "http://localhost/v1"},
+			expected: "http://localhost/v1",
+		},
+		{
+			name: "escaped path params",
+			opt: HttpOptions{
+				BaseURL:    "http://localhost",
+				Url:        "/users/{id}/files/{name}",
+				PathParams: map[string]string{"id": "1", "name": "a b/c?d"},
+			},
+			expected: "http://localhost/users/1/files/a%20b%2Fc%3Fd",
+		},
+		{
+			name:     "base url and absolute url in the query",
+			opt:      HttpOptions{BaseURL: "http://api.local/v1", Url: "/login?next=https://app.local/home"},
+			expected: "http://api.local/v1/login?next=https://app.local/home",
+		},
+		{
+			name:     "base url and query only",
+			opt:      HttpOptions{BaseURL: "http://api.local/v1", Url: "?page=2"},
+			expected: "http://api.local/v1?page=2",
+		},
+		{
+			name:     "braces without path params",
+			opt:      HttpOptions{Url: `http://x/search/{id}?q={"a":1}`},
+			expected: `http://x/search/{id}?q={"a":1}`,
+		},
+		{
+			name: "path params in the path only",
+			opt: HttpOptions{
+				Url:        `http://x/users/{id}?q={"a":1}#{frag}`,
+				PathParams: map[string]string{"id": "1"},
+			},
+			expected: `http://x/users/1?q={"a":1}#{frag}`,
+		},
+		{
+			name: "missing path params",
+			opt:  HttpOptions{Url: "/users/{id}/files/{name}", PathParams: map[string]string{}},
+			err:  "missing path parameter(s) id, name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.opt.url()
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestDoRequestPathParams(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.URL.EscapedPath()))
+	}))
+	defer srv.Close()
+
+	rs := ""
+	opt := &HttpOptions{
+		BaseURL:    srv.URL,
+		Url:        "users/{id}",
+		PathParams: map[string]string{"id": "a/b"},
+		Method:     http.MethodGet,
+	}
+
+	_, err := DoRequest(opt, &rs)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/users/a%2Fb", rs)
+}
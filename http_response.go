@@ -0,0 +1,177 @@
+package helpers
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Response represent the metadata of a response received by Execute and Do
+type Response struct {
+	StatusCode int
+	Status     string
+	Header     http.Header
+	// Trailer is the trailer of the response, set once the body is read.
+	Trailer http.Header
+	// Cookies is the cookies set by the Set-Cookie headers.
+	Cookies []*http.Cookie
+	// URL is the final url of the request after the redirects.
+	URL *url.URL
+	// Redirects is the urls of the redirected requests in order, without the final url.
+	Redirects []*url.URL
+	// Duration is the time spent from the first attempt until the body is read.
+	Duration time.Duration
+	// Timing is the connection timing of the last request.
+	Timing Timing
+	// Body is the raw response body when HttpOptions.KeepRawBody is set.
+	Body []byte
+}
+
+// Timing represent the connection timing of a request, zero for the phases
+// which did not happen e.g. on a reused connection
+type Timing struct {
+	DNSLookup    time.Duration
+	Connect      time.Duration
+	TLSHandshake time.Duration
+	// FirstByte is the time from getting a connection until the first response byte.
+	FirstByte time.Duration
+	// ConnReused reports whether the connection was reused from the pool.
+	ConnReused bool
+}
+
+// newResponse returns the Response of the http response.
+func newResponse(resp *http.Response) *Response {
+	r := &Response{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Header:     resp.Header,
+		Trailer:    resp.Trailer,
+		Cookies:    resp.Cookies(),
+	}
+
+	if resp.Request == nil {
+		return r
+	}
+
+	r.URL = resp.Request.URL
+	for prev := resp.Request.Response; prev != nil && prev.Request != nil; prev = prev.Request.Response {
+		r.Redirects = append([]*url.URL{prev.Request.URL}, r.Redirects...)
+	}
+
+	return r
+}
+
+// timingTrace records the Timing of the requests through an httptrace.ClientTrace.
+type timingTrace struct {
+	mu                                      sync.Mutex
+	result                                  Timing
+	start, dnsStart, connectStart, tlsStart time.Time
+}
+
+// clientTrace returns the httptrace.ClientTrace recording the timing, reset
+// on every new connection request.
+func (t *timingTrace) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		GetConn: func(string) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+
+			t.result = Timing{}
+			t.start = time.Now()
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+
+			t.result.ConnReused = info.Reused
+		},
+		DNSStart: func(httptrace.DNSStartInfo) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+
+			t.dnsStart = time.Now()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+
+			t.result.DNSLookup = time.Since(t.dnsStart)
+		},
+		ConnectStart: func(string, string) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+
+			t.connectStart = time.Now()
+		},
+		ConnectDone: func(string, string, error) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+
+			t.result.Connect = time.Since(t.connectStart)
+		},
+		TLSHandshakeStart: func() {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+
+			t.tlsStart = time.Now()
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+
+			t.result.TLSHandshake = time.Since(t.tlsStart)
+		},
+		GotFirstResponseByte: func() {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+
+			t.result.FirstByte = time.Since(t.start)
+		},
+	}
+}
+
+// timing returns the recorded Timing.
+func (t *timingTrace) timing() Timing {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.result
+}
+
+// Do sends the request of the options with the client, the default client
+// when nil, and returns the response decoded into a new T with its metadata.
+// The context overrides HttpOptions.Ctx when not nil. The errors are the
+// errors of Client.Execute, the Response is nil when no response is received.
+func Do[T any](ctx context.Context, c *Client, opt *HttpOptions) (T, *Response, error) {
+	if c == nil {
+		c = defaultClient
+	}
+
+	if ctx != nil {
+		withCtx := *opt
+		withCtx.Ctx = ctx
+		opt = &withCtx
+	}
+
+	var v T
+	resp, err := c.Execute(opt, &v)
+
+	return v, resp, err
+}
+
+// DoJSON sends the request of the options with the default client and returns
+// the JSON response decoded into a new T with its metadata, whatever its
+// content type unless HttpOptions.Decoder is set.
+func DoJSON[T any](ctx context.Context, opt *HttpOptions) (T, *Response, error) {
+	if opt.Decoder == nil {
+		withDecoder := *opt
+		withDecoder.Decoder = JSONDecoder
+		opt = &withDecoder
+	}
+
+	return Do[T](ctx, nil, opt)
+}
@@ -0,0 +1,188 @@
+package helpers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDo(t *testing.T) {
+	mock := &mockedHTTPClient{response: jsonResponse(http.StatusOK, `{"ping": "pong"}`)}
+	c, _ := NewClient(WithHTTPClient(mock))
+
+	ctx := ContextWithRequestID(context.Background(), "req-1")
+	opt := &HttpOptions{Url: "http://localhost/ping", Method: http.MethodGet}
+
+	rs, resp, err := Do[PingModel](ctx, c, opt)
+
+	assert.NoError(t, err)
+	assert.Equal(t, PingModel{Ping: "pong"}, rs)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "req-1", mock.requests[0].Context().Value(requestIDKey{}))
+	assert.Nil(t, opt.Ctx)
+}
+
+func TestDoPointer(t *testing.T) {
+	mock := &mockedHTTPClient{response: jsonResponse(http.StatusOK, `{"ping": "pong"}`)}
+	c, _ := NewClient(WithHTTPClient(mock))
+
+	rs, _, err := Do[*PingModel](context.Background(), c, &HttpOptions{Url: "http://localhost/ping"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &PingModel{Ping: "pong"}, rs)
+}
+
+func TestDoHTTPError(t *testing.T) {
+	mock := &mockedHTTPClient{response: jsonResponse(http.StatusNotFound, `{"message": "not found"}`)}
+	c, _ := NewClient(WithHTTPClient(mock))
+
+	rs, resp, err := Do[PingModel](context.Background(), c, &HttpOptions{Url: "http://localhost/ping"})
+
+	httpErr := &HTTPError{}
+	assert.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, PingModel{}, rs)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestDoTransportError(t *testing.T) {
+	mock := &mockedHTTPClient{err: errors.New("connection refused")}
+	c, _ := NewClient(WithHTTPClient(mock))
+
+	_, resp, err := Do[PingModel](context.Background(), c, &HttpOptions{Url: "http://localhost/ping"})
+
+	transportErr := &TransportError{}
+	assert.True(t, errors.As(err, &transportErr))
+	assert.Nil(t, resp)
+}
+
+func TestDoJSON(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		time.Sleep(5 * time.Millisecond)
+		w.Write([]byte(`{"ping": "pong"}`))
+	}))
+	defer srv.Close()
+
+	rs, resp, err := DoJSON[map[string]string](context.Background(), &HttpOptions{Url: srv.URL})
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"ping": "pong"}, rs)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.True(t, resp.Duration >= 5*time.Millisecond)
+}
+
+func TestClientExecuteDecodeError(t *testing.T) {
+	mock := &mockedHTTPClient{response: jsonResponse(http.StatusOK, `{`)}
+	c, _ := NewClient(WithHTTPClient(mock))
+
+	resp, err := c.Execute(&HttpOptions{Url: "http://localhost/ping"}, &PingModel{})
+	decodeErr := &DecodeError{}
+	assert.True(t, errors.As(err, &decodeErr))
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	code, err := c.DoRequest(&HttpOptions{Url: "http://localhost/ping"}, &PingModel{})
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusInternalServerError, code)
+}
+
+func TestClientExecuteResponseMetadata(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/moved", http.StatusFound)
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ping", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/ping", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Trailer", "X-Checksum")
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v1"`)
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc"})
+		w.Write([]byte(`{"ping": "pong"}`))
+		w.Header().Set("X-Checksum", "123")
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c, _ := NewClient()
+	rs := &PingModel{}
+
+	resp, err := c.Execute(&HttpOptions{Url: srv.URL + "/old", KeepRawBody: true}, rs)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "pong", rs.Ping)
+	assert.Equal(t, "200 OK", resp.Status)
+	assert.Equal(t, `"v1"`, resp.Header.Get("ETag"))
+	assert.Equal(t, "123", resp.Trailer.Get("X-Checksum"))
+	assert.Len(t, resp.Cookies, 1)
+	assert.Equal(t, "session", resp.Cookies[0].Name)
+	assert.Equal(t, srv.URL+"/ping", resp.URL.String())
+	assert.Len(t, resp.Redirects, 2)
+	assert.Equal(t, srv.URL+"/old", resp.Redirects[0].String())
+	assert.Equal(t, srv.URL+"/moved", resp.Redirects[1].String())
+	assert.Equal(t, `{"ping": "pong"}`, string(resp.Body))
+	assert.True(t, resp.Timing.FirstByte > 0)
+	assert.True(t, resp.Duration >= resp.Timing.FirstByte)
+}
+
+func TestClientExecuteKeepRawBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		response func() *http.Response
+		rs       interface{}
+		keep     bool
+		expected string
+	}{
+		{
+			name:     "decoded",
+			response: jsonResponse(http.StatusOK, `{"ping": "pong"}`),
+			rs:       &PingModel{},
+			keep:     true,
+			expected: `{"ping": "pong"}`,
+		},
+		{
+			name:     "without target",
+			response: jsonResponse(http.StatusOK, `{"ping": "pong"}`),
+			keep:     true,
+			expected: `{"ping": "pong"}`,
+		},
+		{
+			name:     "writer",
+			response: jsonResponse(http.StatusOK, `{"ping": "pong"}`),
+			rs:       &bytes.Buffer{},
+			keep:     true,
+			expected: `{"ping": "pong"}`,
+		},
+		{
+			name:     "http error",
+			response: jsonResponse(http.StatusBadRequest, `{"message": "bad"}`),
+			rs:       &PingModel{},
+			keep:     true,
+			expected: `{"message": "bad"}`,
+		},
+		{
+			name:     "not kept",
+			response: jsonResponse(http.StatusOK, `{"ping": "pong"}`),
+			rs:       &PingModel{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := NewClient(WithHTTPClient(&mockedHTTPClient{response: tt.response}))
+
+			resp, _ := c.Execute(&HttpOptions{Url: "http://localhost/ping", KeepRawBody: tt.keep}, tt.rs)
+
+			assert.Equal(t, tt.expected, string(resp.Body))
+			assert.Equal(t, "http://localhost/ping", resp.URL.String())
+			assert.Empty(t, resp.Redirects)
+		})
+	}
+}
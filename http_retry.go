@@ -0,0 +1,196 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// defaultRetryBaseDelay is the default delay before the first retry.
+	defaultRetryBaseDelay = 100 * time.Millisecond
+	// defaultRetryMaxDelay is the default maximum delay between the attempts.
+	defaultRetryMaxDelay = 5 * time.Second
+)
+
+// defaultRetryableStatus is the default list of the status codes retried.
+var defaultRetryableStatus = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// RetryOptions represent the retry configuration of DoRequest
+type RetryOptions struct {
+	// MaxAttempts is the maximum number of attempts including the first one,
+	// the request is not retried when it is less than 2.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry, doubled on every retry. 100ms when zero.
+	BaseDelay time.Duration
+	// MaxDelay is the maximum delay between the attempts. 5s when zero. The
+	// response is returned without retry when its Retry-After is longer.
+	MaxDelay time.Duration
+	// RetryableStatus is the list of the status codes retried, 429, 502, 503 and 504 when empty.
+	RetryableStatus []int
+	// RetryNonIdempotent enables the retry of the non-idempotent methods such as POST and PATCH.
+	RetryNonIdempotent bool
+}
+
+// doWithRetry sends the request built by newReq until it succeeds, fails with a
+// non-retryable error or the attempts are exhausted. The Retry-After header
+// of the response overrides the backoff delay, the response is returned when
+// it is longer than MaxDelay. The last attempt is returned when the request
+// body cannot be replayed.
+func doWithRetry(
+	ctx context.Context, retry *RetryOptions, method string,
+	newReq func() (*http.Request, error), do func(*http.Request) (*http.Response, error),
+) (*http.Response, error) {
+	attempts := 1
+	if retry != nil && retry.MaxAttempts > 1 && (retry.RetryNonIdempotent || isIdempotent(method)) {
+		attempts = retry.MaxAttempts
+	}
+
+	req, err := newReq()
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := do(req)
+		if attempt >= attempts || !retry.retryable(ctx, resp, err) {
+			return resp, err
+		}
+
+		delay := retry.backoff(attempt)
+		if resp != nil {
+			if after, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				if after > retry.maxDelay() {
+					return resp, err
+				}
+
+				delay = after
+			}
+		}
+
+		next, buildErr := newReq()
+		if errors.Is(buildErr, ErrBodyNotReplayable) {
+			return resp, err
+		}
+
+		if resp != nil {
+			drainBody(resp)
+		}
+
+		if buildErr != nil {
+			return nil, buildErr
+		}
+
+		req = next
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// retryable reports whether the attempt should be retried.
+func (r *RetryOptions) retryable(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) &&
+			!errors.Is(err, ErrCircuitOpen) && !errors.Is(err, ErrRateLimited)
+	}
+
+	status := r.RetryableStatus
+	if len(status) == 0 {
+		status = defaultRetryableStatus
+	}
+
+	for _, code := range status {
+		if resp.StatusCode == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoff returns the exponential backoff delay with jitter for the attempt.
+func (r *RetryOptions) backoff(attempt int) time.Duration {
+	base := r.BaseDelay
+	if base <= 0 {
+		base = defaultRetryBaseDelay
+	}
+
+	maxDelay := r.maxDelay()
+
+	delay := maxDelay
+	if shift := attempt - 1; shift < 32 && base<<shift > 0 && base<<shift < maxDelay {
+		delay = base << shift
+	}
+
+	// equal jitter: half of the delay is fixed, the other half is random.
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// maxDelay returns the maximum delay between the attempts.
+func (r *RetryOptions) maxDelay() time.Duration {
+	if r.MaxDelay <= 0 {
+		return defaultRetryMaxDelay
+	}
+
+	return r.MaxDelay
+}
+
+// parseRetryAfter parses the Retry-After header value in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := date.Sub(now); delay > 0 {
+			return delay, true
+		}
+
+		return 0, true
+	}
+
+	return 0, false
+}
+
+// isIdempotent reports whether the method is idempotent as defined in RFC 9110.
+func isIdempotent(method string) bool {
+	switch method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace,
+		http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// drainBody discards and closes the response body so the connection could be reused.
+func drainBody(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	_ = resp.Body.Close()
+}
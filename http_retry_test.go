@@ -0,0 +1,178 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func flakyServer(failures int32, status int, retryAfter string) (*httptest.Server, *int32) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if atomic.AddInt32(&calls, 1) <= failures {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.WriteHeader(status)
+			return
+		}
+
+		w.Write([]byte(`{"ping": "` + string(body) + `"}`))
+	}))
+
+	return srv, &calls
+}
+
+func TestDoRequestRetry(t *testing.T) {
+	srv, calls := flakyServer(2, http.StatusServiceUnavailable, "")
+	defer srv.Close()
+
+	rs := &PingModel{}
+	opt := &HttpOptions{
+		Ctx:    context.Background(),
+		Url:    srv.URL,
+		Method: http.MethodPut,
+		Data:   []byte("pong"),
+		Retry:  &RetryOptions{MaxAttempts: 3, BaseDelay: time.Millisecond},
+	}
+
+	code, err := DoRequest(opt, rs)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "pong", rs.Ping)
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+}
+
+func TestDoRequestRetryExhausted(t *testing.T) {
+	srv, calls := flakyServer(5, http.StatusBadGateway, "0")
+	defer srv.Close()
+
+	opt := &HttpOptions{
+		Ctx:    context.Background(),
+		Url:    srv.URL,
+		Method: http.MethodGet,
+		Retry:  &RetryOptions{MaxAttempts: 2, BaseDelay: time.Hour},
+	}
+
+	code, err := DoRequest(opt, nil)
+
+	var httpErr *HTTPError
+	assert.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusBadGateway, code)
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+}
+
+func TestDoRequestRetryAfterTooLong(t *testing.T) {
+	srv, calls := flakyServer(5, http.StatusTooManyRequests, "86400")
+	defer srv.Close()
+
+	opt := &HttpOptions{
+		Ctx:    context.Background(),
+		Url:    srv.URL,
+		Method: http.MethodGet,
+		Retry:  &RetryOptions{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Second},
+	}
+
+	start := time.Now()
+	code, err := DoRequest(opt, nil)
+
+	var httpErr *HTTPError
+	assert.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, "86400", httpErr.Header.Get("Retry-After"))
+	assert.Equal(t, http.StatusTooManyRequests, code)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+	assert.True(t, time.Since(start) < time.Second)
+}
+
+func TestDoRequestRetryNonIdempotent(t *testing.T) {
+	srv, calls := flakyServer(1, http.StatusServiceUnavailable, "")
+	defer srv.Close()
+
+	opt := &HttpOptions{
+		Ctx:    context.Background(),
+		Url:    srv.URL,
+		Method: http.MethodPost,
+		Retry:  &RetryOptions{MaxAttempts: 3, BaseDelay: time.Millisecond},
+	}
+
+	code, _ := DoRequest(opt, nil)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+
+	opt.Retry.RetryNonIdempotent = true
+	code, _ = DoRequest(opt, nil)
+	assert.Equal(t, http.StatusOK, code)
+}
+
+func TestDoRequestRetryNotRetryableStatus(t *testing.T) {
+	srv, calls := flakyServer(1, http.StatusBadRequest, "")
+	defer srv.Close()
+
+	opt := &HttpOptions{
+		Ctx:    context.Background(),
+		Url:    srv.URL,
+		Method: http.MethodGet,
+		Retry:  &RetryOptions{MaxAttempts: 3, BaseDelay: time.Millisecond},
+	}
+
+	code, _ := DoRequest(opt, nil)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestDoRequestRetryContextDone(t *testing.T) {
+	srv, _ := flakyServer(5, http.StatusServiceUnavailable, "")
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	opt := &HttpOptions{
+		Ctx:    ctx,
+		Url:    srv.URL,
+		Method: http.MethodGet,
+		Retry:  &RetryOptions{MaxAttempts: 5, BaseDelay: time.Hour, MaxDelay: time.Hour},
+	}
+
+	_, err := DoRequest(opt, nil)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	delay, ok := parseRetryAfter("3", now)
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, delay)
+
+	delay, ok = parseRetryAfter("Mon, 01 Jan 2024 00:00:10 GMT", now)
+	assert.True(t, ok)
+	assert.Equal(t, 10*time.Second, delay)
+
+	_, ok = parseRetryAfter("soon", now)
+	assert.False(t, ok)
+
+	_, ok = parseRetryAfter("", now)
+	assert.False(t, ok)
+}
+
+func TestRetryBackoff(t *testing.T) {
+	retry := &RetryOptions{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	for attempt, max := range map[int]time.Duration{1: 100 * time.Millisecond, 3: 400 * time.Millisecond, 10: time.Second} {
+		delay := retry.backoff(attempt)
+		assert.GreaterOrEqual(t, delay, max/2)
+		assert.LessOrEqual(t, delay, max)
+	}
+}
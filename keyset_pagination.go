@@ -0,0 +1,311 @@
+package helpers
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var (
+	// ErrInvalidCursor is an error message when the cursor is malformed or has been tampered.
+	ErrInvalidCursor = errors.New("Invalid parameter cursor: malformed or tampered")
+	// ErrCursorMismatch is an error message when the cursor was built for another sort.
+	ErrCursorMismatch = errors.New("Invalid parameter cursor: does not match the sort")
+	// ErrMissingSecret is an error message when the Keyset has no secret for sign the cursor.
+	ErrMissingSecret = errors.New("keyset secret is required for sign the cursor")
+)
+
+// Keyset represent the helpers for keyset (cursor) pagination over the sort columns.
+// The last sort column should be unique e.g. the primary key, so the rows are
+// totally ordered, and the sort columns should not hold NULL values.
+type Keyset struct {
+	// Sort is the list of the sort columns, usually the result of ParseSort.
+	Sort []SortSpec
+	// Tag is the struct tag used for read the sort column values from a row.
+	Tag string
+	// Secret is the key used for sign the cursor, required. It should be a
+	// random value of at least 32 bytes.
+	Secret []byte
+	// Dialect is the dialect used for render the query parts, the columns
+	// are not quoted and the placeholders are numbered e.g. $1 when nil.
+	Dialect Dialect
+}
+
+// KeysetQuery represent the query parts for fetch a page with keyset pagination.
+type KeysetQuery struct {
+	// Where is the predicate for the rows after the cursor, empty for the first page.
+	Where string
+	// Args is the list of the arguments for the placeholders of Where.
+	Args []interface{}
+	// OrderBy is the order by fragment for fetch the page.
+	OrderBy string
+	// Backward reports whether the page is fetched in the reversed order for a
+	// previous cursor, the caller should reverse the fetched rows.
+	Backward bool
+}
+
+// keysetCursor is the payload of the cursor.
+type keysetCursor struct {
+	Sort     string        `json:"s"`
+	Values   []interface{} `json:"v"`
+	Backward bool          `json:"b,omitempty"`
+}
+
+// NextCursor returns the cursor for the page after the given row, usually the last row of the page.
+func (k *Keyset) NextCursor(row interface{}) (string, error) {
+	return k.encode(row, false)
+}
+
+// PrevCursor returns the cursor for the page before the given row, usually the first row of the page.
+func (k *Keyset) PrevCursor(row interface{}) (string, error) {
+	return k.encode(row, true)
+}
+
+// Query decodes the cursor into the query parts for fetch the page. The
+// placeholders of the predicate are numbered from startIndex e.g. $1. The
+// error is ErrMissingSecret without Secret, even for the first page.
+func (k *Keyset) Query(cursor string, startIndex int) (*KeysetQuery, error) {
+	if len(k.Secret) == 0 {
+		return nil, ErrMissingSecret
+	}
+
+	d := k.Dialect
+	if d == nil {
+		d = rawDialect
+	}
+
+	if cursor == "" {
+		return &KeysetQuery{OrderBy: RenderOrderBy(d, k.Sort)}, nil
+	}
+
+	c, err := k.decode(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	sort := k.Sort
+	if c.Backward {
+		sort = reverseSort(k.Sort)
+	}
+
+	where, args := keysetPredicate(d, sort, c.Values, startIndex)
+
+	return &KeysetQuery{
+		Where:    where,
+		Args:     args,
+		OrderBy:  RenderOrderBy(d, sort),
+		Backward: c.Backward,
+	}, nil
+}
+
+// encode builds the signed cursor from the sort column values of the row.
+func (k *Keyset) encode(row interface{}, backward bool) (string, error) {
+	if len(k.Secret) == 0 {
+		return "", ErrMissingSecret
+	}
+
+	values, err := k.rowValues(row)
+	if err != nil {
+		return "", err
+	}
+
+	payload, err := json.Marshal(keysetCursor{
+		Sort:     OrderBy(k.Sort),
+		Values:   values,
+		Backward: backward,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(k.sign(payload)), nil
+}
+
+// decode verifies the signature of the cursor and returns its payload.
+func (k *Keyset) decode(cursor string) (*keysetCursor, error) {
+	parts := strings.Split(cursor, ".")
+	if len(parts) != 2 {
+		return nil, ErrInvalidCursor
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	if !hmac.Equal(signature, k.sign(payload)) {
+		return nil, ErrInvalidCursor
+	}
+
+	c := new(keysetCursor)
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	if err := decoder.Decode(c); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	if c.Sort != OrderBy(k.Sort) || len(c.Values) != len(k.Sort) {
+		return nil, ErrCursorMismatch
+	}
+
+	for i, v := range c.Values {
+		if n, ok := v.(json.Number); ok {
+			c.Values[i] = numberValue(n)
+		}
+	}
+
+	return c, nil
+}
+
+// sign returns the HMAC-SHA256 signature of the payload.
+func (k *Keyset) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, k.Secret)
+	mac.Write(payload)
+
+	return mac.Sum(nil)
+}
+
+// rowValues returns the values of the sort columns of the row.
+func (k *Keyset) rowValues(row interface{}) ([]interface{}, error) {
+	fields, err := modelTagFields(row, k.Tag)
+	if err != nil {
+		return nil, err
+	}
+
+	val := reflect.ValueOf(row)
+	if val.Kind() == reflect.Ptr && val.IsNil() {
+		return nil, ErrInvalidModel
+	}
+
+	values := make([]interface{}, 0, len(k.Sort))
+	for _, spec := range k.Sort {
+		f, ok := fields.byName[spec.Column]
+		if !ok {
+			return nil, fmt.Errorf("keyset column %s not found in %T", spec.Column, row)
+		}
+
+		v, ok := fieldByIndex(val, f.index)
+		if !ok {
+			values = append(values, nil)
+			continue
+		}
+
+		if !v.CanInterface() {
+			return nil, fmt.Errorf("keyset column %s is an unexported field of %T", spec.Column, row)
+		}
+
+		values = append(values, v.Interface())
+	}
+
+	return values, nil
+}
+
+// keysetPredicate returns the predicate for the rows after the values in the given sort.
+// A row value comparison is used when all the columns share the same direction.
+func keysetPredicate(d Dialect, sort []SortSpec, values []interface{}, startIndex int) (string, []interface{}) {
+	if len(sort) == 0 {
+		return "", nil
+	}
+
+	sameDirection := true
+	for _, spec := range sort[1:] {
+		if spec.Direction != sort[0].Direction {
+			sameDirection = false
+		}
+	}
+
+	if sameDirection {
+		columns := make([]string, 0, len(sort))
+		placeholders := make([]string, 0, len(sort))
+		for i, spec := range sort {
+			columns = append(columns, d.QuoteIdent(spec.Column))
+			placeholders = append(placeholders, d.Placeholder(startIndex+i))
+		}
+
+		if len(sort) == 1 {
+			return fmt.Sprintf("%s %s %s", columns[0], keysetOperator(sort[0]), placeholders[0]), values
+		}
+
+		return fmt.Sprintf(
+			"(%s) %s (%s)",
+			strings.Join(columns, ", "), keysetOperator(sort[0]), strings.Join(placeholders, ", "),
+		), values
+	}
+
+	// (a > $1) OR (a = $1 AND b < $2) ...
+	args := []interface{}{}
+	ors := make([]string, 0, len(sort))
+	for i, spec := range sort {
+		ands := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			ands = append(ands, fmt.Sprintf("%s = %s", d.QuoteIdent(sort[j].Column), d.Placeholder(startIndex+len(args))))
+			args = append(args, values[j])
+		}
+
+		ands = append(ands, fmt.Sprintf(
+			"%s %s %s", d.QuoteIdent(spec.Column), keysetOperator(spec), d.Placeholder(startIndex+len(args)),
+		))
+		args = append(args, values[i])
+
+		ors = append(ors, "("+strings.Join(ands, " AND ")+")")
+	}
+
+	return "(" + strings.Join(ors, " OR ") + ")", args
+}
+
+// keysetOperator returns the comparison operator for the rows after the value in the given direction.
+func keysetOperator(spec SortSpec) string {
+	if spec.Direction == SortDesc {
+		return "<"
+	}
+
+	return ">"
+}
+
+// reverseSort returns the given sort with the reversed direction and nulls placement.
+func reverseSort(sort []SortSpec) []SortSpec {
+	out := make([]SortSpec, 0, len(sort))
+	for _, spec := range sort {
+		if spec.Direction == SortDesc {
+			spec.Direction = SortAsc
+		} else {
+			spec.Direction = SortDesc
+		}
+
+		switch spec.Nulls {
+		case NullsFirst:
+			spec.Nulls = NullsLast
+		case NullsLast:
+			spec.Nulls = NullsFirst
+		}
+
+		out = append(out, spec)
+	}
+
+	return out
+}
+
+// numberValue converts the JSON number into an int64 or a float64.
+func numberValue(n json.Number) interface{} {
+	if i, err := n.Int64(); err == nil {
+		return i
+	}
+
+	if f, err := n.Float64(); err == nil {
+		return f
+	}
+
+	return n.String()
+}
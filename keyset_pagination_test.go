@@ -0,0 +1,136 @@
+package helpers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeysetFirstPage(t *testing.T) {
+	keyset := &Keyset{Sort: []SortSpec{{Column: "id", Direction: SortAsc}}, Tag: "db", Secret: []byte("secret")}
+
+	query, err := keyset.Query("", 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &KeysetQuery{OrderBy: "id ASC"}, query)
+}
+
+func TestKeysetNextCursor(t *testing.T) {
+	sort, _ := ParseSort("created_at,id", "db", UserModel{})
+	keyset := &Keyset{Sort: sort, Tag: "db", Secret: []byte("secret")}
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	cursor, err := keyset.NextCursor(&UserModel{ID: "10", CreatedAt: createdAt})
+	assert.NoError(t, err)
+
+	query, err := keyset.Query(cursor, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, "(created_at, id) > ($3, $4)", query.Where)
+	assert.Equal(t, []interface{}{"2024-01-02T03:04:05Z", "10"}, query.Args)
+	assert.Equal(t, "created_at ASC, id ASC", query.OrderBy)
+	assert.False(t, query.Backward)
+}
+
+func TestKeysetPrevCursorMixedDirection(t *testing.T) {
+	sort, _ := ParseSort("-name,id", "db", UserModel{})
+	keyset := &Keyset{Sort: sort, Tag: "db", Secret: []byte("secret")}
+
+	cursor, err := keyset.PrevCursor(UserModel{ID: "10", Name: "foo"})
+	assert.NoError(t, err)
+
+	query, err := keyset.Query(cursor, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "((name > $1) OR (name = $2 AND id < $3))", query.Where)
+	assert.Equal(t, []interface{}{"foo", "foo", "10"}, query.Args)
+	assert.Equal(t, "name ASC, id DESC", query.OrderBy)
+	assert.True(t, query.Backward)
+}
+
+func TestKeysetNumberValue(t *testing.T) {
+	type Row struct {
+		ID int `db:"id"`
+	}
+	keyset := &Keyset{Sort: []SortSpec{{Column: "id", Direction: SortDesc}}, Tag: "db", Secret: []byte("secret")}
+
+	cursor, _ := keyset.NextCursor(Row{ID: 42})
+	query, err := keyset.Query(cursor, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "id < $1", query.Where)
+	assert.Equal(t, []interface{}{int64(42)}, query.Args)
+}
+
+func TestKeysetTamperedCursor(t *testing.T) {
+	keyset := &Keyset{Sort: []SortSpec{{Column: "id", Direction: SortAsc}}, Tag: "db", Secret: []byte("secret")}
+	cursor, _ := keyset.NextCursor(UserModel{ID: "10"})
+
+	other := &Keyset{Sort: keyset.Sort, Tag: "db", Secret: []byte("other")}
+	_, err := other.Query(cursor, 1)
+	assert.Equal(t, ErrInvalidCursor, err)
+
+	_, err = keyset.Query("x"+cursor, 1)
+	assert.Equal(t, ErrInvalidCursor, err)
+
+	_, err = keyset.Query("foo", 1)
+	assert.Equal(t, ErrInvalidCursor, err)
+}
+
+func TestKeysetCursorMismatch(t *testing.T) {
+	keyset := &Keyset{Sort: []SortSpec{{Column: "id", Direction: SortAsc}}, Tag: "db", Secret: []byte("secret")}
+	cursor, _ := keyset.NextCursor(UserModel{ID: "10"})
+
+	keyset.Sort = []SortSpec{{Column: "name", Direction: SortAsc}}
+	_, err := keyset.Query(cursor, 1)
+
+	assert.Equal(t, ErrCursorMismatch, err)
+}
+
+func TestKeysetInvalidRow(t *testing.T) {
+	keyset := &Keyset{Sort: []SortSpec{{Column: "x", Direction: SortAsc}}, Tag: "db", Secret: []byte("secret")}
+
+	_, err := keyset.NextCursor(UserModel{})
+	assert.Error(t, err)
+
+	_, err = keyset.NextCursor("row")
+	assert.Error(t, err)
+}
+
+func TestKeysetEmbeddedRow(t *testing.T) {
+	keyset := &Keyset{Sort: []SortSpec{{Column: "id", Direction: SortAsc}}, Tag: "db", Secret: []byte("secret")}
+	cursor, err := keyset.NextCursor(&PostModel{BaseModel: BaseModel{ID: "7"}})
+	assert.NoError(t, err)
+
+	query, err := keyset.Query(cursor, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"7"}, query.Args)
+
+	_, err = keyset.NextCursor((*PostModel)(nil))
+	assert.Equal(t, ErrInvalidModel, err)
+}
+
+func TestKeysetMissingSecret(t *testing.T) {
+	keyset := &Keyset{Sort: []SortSpec{{Column: "id", Direction: SortAsc}}, Tag: "db"}
+
+	_, err := keyset.NextCursor(UserModel{})
+	assert.Equal(t, ErrMissingSecret, err)
+
+	_, err = keyset.PrevCursor(UserModel{})
+	assert.Equal(t, ErrMissingSecret, err)
+
+	_, err = keyset.Query("", 1)
+	assert.Equal(t, ErrMissingSecret, err)
+
+	signed := &Keyset{Sort: keyset.Sort, Tag: "db", Secret: []byte("secret")}
+	cursor, _ := signed.NextCursor(UserModel{})
+	keyset.Secret = []byte{}
+	_, err = keyset.Query(cursor, 1)
+	assert.Equal(t, ErrMissingSecret, err)
+}
+
+func TestKeysetUnexportedColumn(t *testing.T) {
+	keyset := &Keyset{Sort: []SortSpec{{Column: "internal", Direction: SortAsc}}, Tag: "db", Secret: []byte("secret")}
+
+	_, err := keyset.NextCursor(PostModel{internal: "foo"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,189 @@
+package helpers
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// DefaultSortParam is the default name of the sort parameter.
+const DefaultSortParam = "sort"
+
+// ListQuery represent the pagination and the sorting of a list request
+type ListQuery struct {
+	Offset  int
+	PerPage int
+	Page    int
+	Sort    []SortSpec
+	OrderBy string
+
+	pageParam    string
+	perPageParam string
+	offsetParam  string
+}
+
+// Render returns the order by, limit and offset clauses of the list query in the
+// dialect e.g. `ORDER BY "id" DESC LIMIT 10 OFFSET 20`.
+func (q *ListQuery) Render(d Dialect) string {
+	limit := RenderLimit(d, q.PerPage, q.Offset)
+	if len(q.Sort) == 0 {
+		return limit
+	}
+
+	return "ORDER BY " + RenderOrderBy(d, q.Sort) + " " + limit
+}
+
+// PageInfo returns the pagination metadata of the list query for the given
+// total count. For limit/offset pagination the links carry the offset.
+func (q *ListQuery) PageInfo(total int) *PageInfo {
+	info := NewPageInfo(q.Offset, q.PerPage, q.Page, total)
+	info.PageParam = q.pageParam
+	info.PerPageParam = q.perPageParam
+	info.OffsetParam = q.offsetParam
+
+	if q.offsetParam != "" {
+		info.HasPrev = q.Offset > 0
+		info.HasNext = q.Offset+q.PerPage < total
+	}
+
+	return info
+}
+
+// ListParams represent the configuration for parse a list request. The zero
+// value reads the "page", "per_page" and "sort" parameters.
+type ListParams struct {
+	// PageParam is the name of the page number parameter, "page" when empty.
+	PageParam string
+	// PerPageParam is the name of the page size parameter, "per_page" when empty.
+	// Set it to "limit" together with OffsetParam for limit/offset pagination.
+	PerPageParam string
+	// OffsetParam is the name of the offset parameter. When it is set the
+	// offset is read from this parameter instead of the page number.
+	OffsetParam string
+	// SortParam is the name of the sort parameter, "sort" when empty.
+	SortParam string
+	// TagMapping names the sort fields by its external tag e.g. "json" and the
+	// columns by its internal tag e.g. "db", like ParseSortWithMapping. The tag
+	// given to Parse is used for both when zero.
+	TagMapping TagMapping
+	// Paginator is the paginator for the page size and the page number limits.
+	Paginator *Paginator
+}
+
+// defaultListParams is the ListParams used by ParseListQuery and ParseListRequest.
+var defaultListParams = &ListParams{}
+
+// ParseListQuery represent the helpers for parse the pagination and the sorting
+// of a list request from the query values with the default parameter names.
+func ParseListQuery(values url.Values, model interface{}, tag string) (*ListQuery, error) {
+	return defaultListParams.Parse(values, model, tag)
+}
+
+// ParseListRequest represent the helpers for parse the pagination and the sorting
+// of a list request from the request query string with the default parameter names.
+func ParseListRequest(r *http.Request, model interface{}, tag string) (*ListQuery, error) {
+	return defaultListParams.ParseRequest(r, model, tag)
+}
+
+// ParseRequest parses the pagination and the sorting from the request query string.
+func (p *ListParams) ParseRequest(r *http.Request, model interface{}, tag string) (*ListQuery, error) {
+	return p.Parse(r.URL.Query(), model, tag)
+}
+
+// Parse parses the pagination and the sorting from the query values. The
+// error is a *PaginationError or an *InvalidFieldsError naming the failed parameter.
+func (p *ListParams) Parse(values url.Values, model interface{}, tag string) (*ListQuery, error) {
+	paginator := p.Paginator
+	if paginator == nil {
+		paginator = defaultPaginator
+	}
+
+	perPageParam := p.param(p.PerPageParam, DefaultPerPageParam)
+	pageParam := p.param(p.PageParam, DefaultPageParam)
+
+	offset, perPage, page, err := paginator.Paginate(values.Get(perPageParam), values.Get(pageParam))
+	if err != nil {
+		return nil, p.renameParam(err, perPageParam, pageParam)
+	}
+
+	if p.OffsetParam != "" {
+		offset, err = p.offset(values.Get(p.OffsetParam))
+		if err != nil {
+			return nil, err
+		}
+
+		page = offset/perPage + 1
+	}
+
+	sortParam := p.param(p.SortParam, DefaultSortParam)
+	mapping := p.TagMapping
+	if mapping == (TagMapping{}) {
+		mapping = TagMapping{External: tag, Internal: tag}
+	}
+
+	sort, err := ParseSortWithMapping(values.Get(sortParam), mapping, model)
+	if err != nil {
+		if fieldsErr, ok := err.(*InvalidFieldsError); ok {
+			fieldsErr.Param = sortParam
+		}
+
+		return nil, err
+	}
+
+	return &ListQuery{
+		Offset:  offset,
+		PerPage: perPage,
+		Page:    page,
+		Sort:    sort,
+		OrderBy: OrderBy(sort),
+
+		pageParam:    pageParam,
+		perPageParam: perPageParam,
+		offsetParam:  p.OffsetParam,
+	}, nil
+}
+
+// offset parses and validates the offset value.
+func (p *ListParams) offset(value string) (int, error) {
+	if len(value) == 0 {
+		return 0, nil
+	}
+
+	offset, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, &PaginationError{Param: p.OffsetParam, Value: value, Reason: "not an int"}
+	}
+
+	if offset < 0 {
+		if p.Paginator != nil && p.Paginator.Policy == LimitError {
+			return 0, &PaginationError{Param: p.OffsetParam, Value: value, Reason: "must be at least 0"}
+		}
+
+		offset = 0
+	}
+
+	return offset, nil
+}
+
+// renameParam replaces the default parameter names of the pagination error with the configured names.
+func (p *ListParams) renameParam(err error, perPageParam, pageParam string) error {
+	if paginationErr, ok := err.(*PaginationError); ok {
+		switch paginationErr.Param {
+		case DefaultPerPageParam:
+			paginationErr.Param = perPageParam
+		case DefaultPageParam:
+			paginationErr.Param = pageParam
+		}
+	}
+
+	return err
+}
+
+// param returns the configured parameter name or the default name when empty.
+func (p *ListParams) param(name, defaultName string) string {
+	if name == "" {
+		return defaultName
+	}
+
+	return name
+}
@@ -0,0 +1,103 @@
+package helpers
+
+import (
+	"errors"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseListQuery(t *testing.T) {
+	values := url.Values{"page": {"3"}, "per_page": {"20"}, "sort": {"-created_at,id"}}
+	query, err := ParseListQuery(values, UserModel{}, "db")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 40, query.Offset)
+	assert.Equal(t, 20, query.PerPage)
+	assert.Equal(t, 3, query.Page)
+	assert.Equal(t, "created_at DESC, id ASC", query.OrderBy)
+	assert.Len(t, query.Sort, 2)
+}
+
+func TestParseListRequest(t *testing.T) {
+	r := httptest.NewRequest("GET", "/users?sort=name", nil)
+	query, err := ParseListRequest(r, UserModel{}, "db")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, query.Offset)
+	assert.Equal(t, 10, query.PerPage)
+	assert.Equal(t, 1, query.Page)
+	assert.Equal(t, "name ASC", query.OrderBy)
+}
+
+func TestListParamsLimitOffset(t *testing.T) {
+	params := &ListParams{PerPageParam: "limit", OffsetParam: "offset", SortParam: "order"}
+	values := url.Values{"limit": {"25"}, "offset": {"50"}, "order": {"-id"}}
+	query, err := params.Parse(values, UserModel{}, "db")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 50, query.Offset)
+	assert.Equal(t, 25, query.PerPage)
+	assert.Equal(t, 3, query.Page)
+	assert.Equal(t, "id DESC", query.OrderBy)
+
+	link, _ := query.PageInfo(100).LinkHeader("/users")
+	assert.Equal(t, `</users?limit=25&offset=0>; rel="first", `+
+		`</users?limit=25&offset=25>; rel="prev", `+
+		`</users?limit=25&offset=75>; rel="next", `+
+		`</users?limit=25&offset=75>; rel="last"`, link)
+
+	links, _ := query.PageInfo(100).Links("/users")
+	next, _ := url.Parse(links.Next)
+	nextQuery, err := params.Parse(next.Query(), UserModel{}, "db")
+	assert.NoError(t, err)
+	assert.Equal(t, 75, nextQuery.Offset)
+	assert.Equal(t, 4, nextQuery.Page)
+}
+
+func TestListParamsLimitOffsetUnaligned(t *testing.T) {
+	params := &ListParams{PerPageParam: "limit", OffsetParam: "offset"}
+	query, err := params.Parse(url.Values{"limit": {"10"}, "offset": {"5"}}, UserModel{}, "db")
+	assert.NoError(t, err)
+
+	links, _ := query.PageInfo(12).Links("/users")
+	assert.Equal(t, "/users?limit=10&offset=0", links.Prev)
+	assert.Equal(t, "", links.Next)
+
+	links, _ = query.PageInfo(30).Links("/users")
+	assert.Equal(t, "/users?limit=10&offset=15", links.Next)
+}
+
+func TestListParamsErrors(t *testing.T) {
+	params := &ListParams{PerPageParam: "limit", OffsetParam: "offset", SortParam: "order"}
+
+	var paginationErr *PaginationError
+	_, err := params.Parse(url.Values{"limit": {"a"}}, UserModel{}, "db")
+	assert.True(t, errors.As(err, &paginationErr))
+	assert.Equal(t, "Invalid parameter limit: not an int", err.Error())
+
+	_, err = params.Parse(url.Values{"offset": {"b"}}, UserModel{}, "db")
+	assert.True(t, errors.As(err, &paginationErr))
+	assert.Equal(t, "offset", paginationErr.Param)
+
+	var fieldsErr *InvalidFieldsError
+	_, err = params.Parse(url.Values{"order": {"x"}}, UserModel{}, "db")
+	assert.True(t, errors.As(err, &fieldsErr))
+	assert.Equal(t, "order", fieldsErr.Param)
+}
+
+func TestListParamsTagMapping(t *testing.T) {
+	params := &ListParams{TagMapping: JSONToDB}
+
+	query, err := params.Parse(url.Values{"sort": {"-createdAt,fullName"}}, AccountModel{}, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "created_at DESC, full_name ASC", query.OrderBy)
+
+	var fieldsErr *InvalidFieldsError
+	_, err = params.Parse(url.Values{"sort": {"created_at"}}, AccountModel{}, "")
+	assert.True(t, errors.As(err, &fieldsErr))
+	assert.Equal(t, "sort", fieldsErr.Param)
+	assert.Equal(t, []string{"created_at"}, fieldsErr.Rejected)
+}
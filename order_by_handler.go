@@ -1,30 +1,21 @@
 package helpers
 
-import (
-	"fmt"
-	"strings"
-)
-
 // OrderByHandler represent the helpers for handle order by query
 func OrderByHandler(field, tag string, model interface{}) string {
-	if field == "" {
-		return field
-	}
+	specs, _ := ParseSort(field, tag, model)
 
-	sort := "ASC"
-	splitDesc := strings.Split(field, "")
-	if len(splitDesc) > 0 {
-		if splitDesc[0] == "-" {
-			sort = "DESC"
-		}
-	}
-
-	field = strings.Replace(field, "-", "", -1)
-	field = CheckMatchTag(model, field, tag)
+	return OrderBy(specs)
+}
 
-	if field == "" {
-		return field
+// OrderByMultiHandler represent the helpers for handle multi-column order by query
+// such as "-created_at,name". Each field accepts the "-field", "+field",
+// "field:desc" and "field:asc" forms. Duplicated columns are ignored and the
+// fields not found in the model tag are dropped and returned as the second value.
+func OrderByMultiHandler(fields, tag string, model interface{}) (string, []string) {
+	specs, err := ParseSort(fields, tag, model)
+	if err, ok := err.(*InvalidFieldsError); ok {
+		return OrderBy(specs), err.Rejected
 	}
 
-	return fmt.Sprintf("%s %s", field, sort)
+	return OrderBy(specs), []string{}
 }
@@ -37,3 +37,36 @@ func TestOrderByHandleNotFoundField(t *testing.T) {
 
 	assert.Equal(t, expectedOrderBy, orderBy)
 }
+
+func TestOrderByHandlerDescSuffix(t *testing.T) {
+	selectField := "created_at:desc"
+	orderBy := OrderByHandler(selectField, "db", UserModel{})
+	expectedOrderBy := "created_at DESC"
+
+	assert.Equal(t, expectedOrderBy, orderBy)
+}
+
+func TestOrderByMultiHandler(t *testing.T) {
+	selectField := "-created_at, +name,email:desc,id:ASC"
+	orderBy, unknown := OrderByMultiHandler(selectField, "db", UserModel{})
+	expectedOrderBy := "created_at DESC, name ASC, email DESC, id ASC"
+
+	assert.Equal(t, expectedOrderBy, orderBy)
+	assert.Empty(t, unknown)
+}
+
+func TestOrderByMultiHandlerUnknownAndDuplicate(t *testing.T) {
+	selectField := "-id,x,id,,name:sideways"
+	orderBy, unknown := OrderByMultiHandler(selectField, "db", UserModel{})
+	expectedOrderBy := "id DESC"
+
+	assert.Equal(t, expectedOrderBy, orderBy)
+	assert.Equal(t, []string{"x", "name:sideways"}, unknown)
+}
+
+func TestOrderByMultiHandlerEmptyField(t *testing.T) {
+	orderBy, unknown := OrderByMultiHandler("", "db", UserModel{})
+
+	assert.Equal(t, "", orderBy)
+	assert.Empty(t, unknown)
+}
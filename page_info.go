@@ -0,0 +1,173 @@
+package helpers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultPageParam is the default name of the page number parameter.
+	DefaultPageParam = "page"
+	// DefaultPerPageParam is the default name of the page size parameter.
+	DefaultPerPageParam = "per_page"
+)
+
+// PageInfo represent the pagination metadata of a list response
+type PageInfo struct {
+	Page       int  `json:"page"`
+	PerPage    int  `json:"per_page"`
+	Offset     int  `json:"offset"`
+	Total      int  `json:"total"`
+	TotalPages int  `json:"total_pages"`
+	HasNext    bool `json:"has_next"`
+	HasPrev    bool `json:"has_prev"`
+
+	// PageParam is the name of the page number parameter in the links, "page" when empty.
+	PageParam string `json:"-"`
+	// PerPageParam is the name of the page size parameter in the links, "per_page" when empty.
+	PerPageParam string `json:"-"`
+	// OffsetParam is the name of the offset parameter for limit/offset
+	// pagination. When it is set the links carry the offset instead of the page number.
+	OffsetParam string `json:"-"`
+}
+
+// PageLinks represent the links to the other pages of a list response
+type PageLinks struct {
+	First string `json:"first,omitempty"`
+	Prev  string `json:"prev,omitempty"`
+	Next  string `json:"next,omitempty"`
+	Last  string `json:"last,omitempty"`
+}
+
+// PageMetadata represent the pagination metadata with the page links
+type PageMetadata struct {
+	PageInfo
+	Links PageLinks `json:"links"`
+}
+
+// NewPageInfo returns the pagination metadata from the PaginationSetter result and the total count.
+func NewPageInfo(offset, perPage, showPage, total int) *PageInfo {
+	totalPages := 0
+	if perPage > 0 {
+		totalPages = (total + perPage - 1) / perPage
+	}
+
+	return &PageInfo{
+		Page:       showPage,
+		PerPage:    perPage,
+		Offset:     offset,
+		Total:      total,
+		TotalPages: totalPages,
+		HasNext:    showPage < totalPages,
+		HasPrev:    showPage > 1,
+	}
+}
+
+// Links returns the links to the first, previous, next and last pages for the
+// given base URL. The other query parameters of the base URL are kept.
+func (p *PageInfo) Links(baseURL string) (*PageLinks, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	lastPage := p.TotalPages
+	if lastPage < 1 {
+		lastPage = 1
+	}
+
+	links := &PageLinks{
+		First: p.pageURL(u, 1, 0),
+		Last:  p.pageURL(u, lastPage, (lastPage-1)*p.PerPage),
+	}
+
+	if p.HasPrev {
+		prevOffset := p.Offset - p.PerPage
+		if prevOffset < 0 {
+			prevOffset = 0
+		}
+
+		links.Prev = p.pageURL(u, p.Page-1, prevOffset)
+	}
+
+	if p.HasNext {
+		links.Next = p.pageURL(u, p.Page+1, p.Offset+p.PerPage)
+	}
+
+	return links, nil
+}
+
+// Metadata returns the pagination metadata with the page links for the given base URL.
+func (p *PageInfo) Metadata(baseURL string) (*PageMetadata, error) {
+	links, err := p.Links(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PageMetadata{PageInfo: *p, Links: *links}, nil
+}
+
+// LinkHeader returns the RFC 8288 Link header value for the given base URL.
+func (p *PageInfo) LinkHeader(baseURL string) (string, error) {
+	links, err := p.Links(baseURL)
+	if err != nil {
+		return "", err
+	}
+
+	out := []string{}
+	for _, link := range []struct{ rel, url string }{
+		{"first", links.First},
+		{"prev", links.Prev},
+		{"next", links.Next},
+		{"last", links.Last},
+	} {
+		if link.url != "" {
+			out = append(out, fmt.Sprintf(`<%s>; rel="%s"`, link.url, link.rel))
+		}
+	}
+
+	return strings.Join(out, ", "), nil
+}
+
+// SetHeaders sets the Link and X-Total-Count headers for the given base URL.
+func (p *PageInfo) SetHeaders(header http.Header, baseURL string) error {
+	link, err := p.LinkHeader(baseURL)
+	if err != nil {
+		return err
+	}
+
+	header.Set("Link", link)
+	header.Set("X-Total-Count", strconv.Itoa(p.Total))
+
+	return nil
+}
+
+// pageURL returns the base URL with the given page number, or the given
+// offset when OffsetParam is set, and the page size.
+func (p *PageInfo) pageURL(u *url.URL, page, offset int) string {
+	pageParam := p.PageParam
+	if pageParam == "" {
+		pageParam = DefaultPageParam
+	}
+
+	perPageParam := p.PerPageParam
+	if perPageParam == "" {
+		perPageParam = DefaultPerPageParam
+	}
+
+	q := u.Query()
+	if p.OffsetParam != "" {
+		q.Set(p.OffsetParam, strconv.Itoa(offset))
+	} else {
+		q.Set(pageParam, strconv.Itoa(page))
+	}
+	q.Set(perPageParam, strconv.Itoa(p.PerPage))
+
+	out := *u
+	out.RawQuery = q.Encode()
+
+	return out.String()
+}
@@ -0,0 +1,80 @@
+package helpers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPageInfo(t *testing.T) {
+	offset, perPage, showPage, _ := PaginationSetter("10", "2")
+	info := NewPageInfo(offset, perPage, showPage, 35)
+
+	assert.Equal(t, &PageInfo{
+		Page:       2,
+		PerPage:    10,
+		Offset:     10,
+		Total:      35,
+		TotalPages: 4,
+		HasNext:    true,
+		HasPrev:    true,
+	}, info)
+}
+
+func TestPageInfoLastPage(t *testing.T) {
+	info := NewPageInfo(30, 10, 4, 35)
+
+	assert.False(t, info.HasNext)
+	assert.True(t, info.HasPrev)
+}
+
+func TestPageInfoLinkHeader(t *testing.T) {
+	info := NewPageInfo(10, 10, 2, 35)
+	link, err := info.LinkHeader("https://api.example.com/users?status=active&page=2")
+	expectedLink := `<https://api.example.com/users?page=1&per_page=10&status=active>; rel="first", ` +
+		`<https://api.example.com/users?page=1&per_page=10&status=active>; rel="prev", ` +
+		`<https://api.example.com/users?page=3&per_page=10&status=active>; rel="next", ` +
+		`<https://api.example.com/users?page=4&per_page=10&status=active>; rel="last"`
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedLink, link)
+}
+
+func TestPageInfoLinkHeaderCustomParam(t *testing.T) {
+	info := NewPageInfo(0, 10, 1, 0)
+	info.PageParam = "p"
+	info.PerPageParam = "limit"
+	link, err := info.LinkHeader("/users")
+	expectedLink := `</users?limit=10&p=1>; rel="first", </users?limit=10&p=1>; rel="last"`
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedLink, link)
+}
+
+func TestPageInfoSetHeaders(t *testing.T) {
+	info := NewPageInfo(0, 10, 1, 5)
+	header := http.Header{}
+	err := info.SetHeaders(header, "/users")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "5", header.Get("X-Total-Count"))
+	assert.Equal(t, `</users?page=1&per_page=10>; rel="first", </users?page=1&per_page=10>; rel="last"`, header.Get("Link"))
+
+	err = info.SetHeaders(header, "%zz")
+	assert.Error(t, err)
+}
+
+func TestPageInfoMetadata(t *testing.T) {
+	info := NewPageInfo(0, 10, 1, 15)
+	metadata, err := info.Metadata("/users")
+	assert.NoError(t, err)
+
+	b, err := json.Marshal(metadata)
+	expectedJSON := `{"page":1,"per_page":10,"offset":0,"total":15,"total_pages":2,"has_next":true,"has_prev":false,` +
+		`"links":{"first":"/users?page=1&per_page=10","next":"/users?page=2&per_page=10","last":"/users?page=2&per_page=10"}}`
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, expectedJSON, string(b))
+}
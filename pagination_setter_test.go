@@ -31,3 +31,10 @@ func TestPaginationSetter(t *testing.T) {
 
 	assert.Equal(t, nil, err)
 }
+
+func TestPaginationSetterOverflow(t *testing.T) {
+	offset, _, _, err := PaginationSetter("10", "9223372036854775807")
+
+	assert.Equal(t, nil, err)
+	assert.True(t, offset >= 0)
+}
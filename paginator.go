@@ -0,0 +1,149 @@
+package helpers
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// defaultPerPage is the default page size when neither the request nor the Paginator sets it.
+const defaultPerPage = 10
+
+// LimitPolicy represent the policy for the pagination values out of the allowed range
+type LimitPolicy int
+
+const (
+	// LimitClamp replaces the out of range values with the nearest allowed
+	// value, except a per_page below 1 which falls back to the default page size.
+	LimitClamp LimitPolicy = iota
+	// LimitError rejects the out of range values with a *PaginationError.
+	LimitError
+)
+
+// PaginationError represent the error for an invalid pagination parameter
+type PaginationError struct {
+	// Param is the name of the failed parameter e.g. "per_page".
+	Param string
+	// Value is the raw value of the failed parameter.
+	Value string
+	// Reason is the reason of the failure e.g. "not an int".
+	Reason string
+}
+
+// Error returns the error message e.g. "Invalid parameter per_page: not an int".
+func (e *PaginationError) Error() string {
+	return fmt.Sprintf("Invalid parameter %s: %s", e.Param, e.Reason)
+}
+
+// Paginator represent the helpers for configurable offset pagination
+type Paginator struct {
+	// DefaultPerPage is the page size when per_page is empty, or below 1 with
+	// LimitClamp. 10 when zero.
+	DefaultPerPage int
+	// MaxPerPage is the maximum page size, unbounded when zero.
+	MaxPerPage int
+	// MaxPage is the maximum page number, unbounded when zero.
+	MaxPage int
+	// Policy is the policy for the values out of the allowed range.
+	Policy LimitPolicy
+}
+
+// defaultPaginator is the Paginator used by PaginationSetter.
+var defaultPaginator = &Paginator{}
+
+// Paginate returns the offset, the page size and the page number for the
+// given per_page and page values.
+func (p *Paginator) Paginate(perPageString, pageString string) (int, int, int, error) {
+	perPage, err := p.perPage(perPageString)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	page, err := p.page(pageString, perPage)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	offset := (page - 1) * perPage
+
+	return offset, perPage, page, nil
+}
+
+// perPage parses and validates the page size.
+func (p *Paginator) perPage(value string) (int, error) {
+	defaultValue := p.DefaultPerPage
+	if defaultValue < 1 {
+		defaultValue = defaultPerPage
+	}
+
+	if len(value) == 0 {
+		return defaultValue, nil
+	}
+
+	perPage, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, &PaginationError{Param: "per_page", Value: value, Reason: "not an int"}
+	}
+
+	if perPage < 1 {
+		if p.Policy == LimitError {
+			return 0, &PaginationError{Param: "per_page", Value: value, Reason: "must be at least 1"}
+		}
+
+		perPage = defaultValue
+	}
+
+	if p.MaxPerPage > 0 && perPage > p.MaxPerPage {
+		if p.Policy == LimitError {
+			return 0, &PaginationError{
+				Param:  "per_page",
+				Value:  value,
+				Reason: fmt.Sprintf("must be at most %d", p.MaxPerPage),
+			}
+		}
+
+		perPage = p.MaxPerPage
+	}
+
+	return perPage, nil
+}
+
+// page parses and validates the page number. The page number is bounded by
+// MaxPage and by the largest page whose offset fits in an int for the page size.
+func (p *Paginator) page(value string, perPage int) (int, error) {
+	if len(value) == 0 {
+		return 1, nil
+	}
+
+	page, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, &PaginationError{Param: "page", Value: value, Reason: "not an int"}
+	}
+
+	if page < 1 {
+		if p.Policy == LimitError {
+			return 0, &PaginationError{Param: "page", Value: value, Reason: "must be at least 1"}
+		}
+
+		page = 1
+	}
+
+	maxPage := math.MaxInt/perPage + 1
+	if p.MaxPage > 0 && p.MaxPage < maxPage {
+		maxPage = p.MaxPage
+	}
+
+	if page > maxPage {
+		if p.Policy == LimitError {
+			return 0, &PaginationError{
+				Param:  "page",
+				Value:  value,
+				Reason: fmt.Sprintf("must be at most %d", maxPage),
+			}
+		}
+
+		page = maxPage
+	}
+
+	return page, nil
+}
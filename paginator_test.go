@@ -0,0 +1,77 @@
+package helpers
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginatorDefault(t *testing.T) {
+	paginator := &Paginator{DefaultPerPage: 25}
+	offset, perPage, page, err := paginator.Paginate("", "3")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 50, offset)
+	assert.Equal(t, 25, perPage)
+	assert.Equal(t, 3, page)
+}
+
+func TestPaginatorClamp(t *testing.T) {
+	paginator := &Paginator{MaxPerPage: 100, MaxPage: 5}
+
+	offset, perPage, page, err := paginator.Paginate("1000000", "9")
+	assert.NoError(t, err)
+	assert.Equal(t, 400, offset)
+	assert.Equal(t, 100, perPage)
+	assert.Equal(t, 5, page)
+
+	_, perPage, page, err = paginator.Paginate("-5", "-1")
+	assert.NoError(t, err)
+	assert.Equal(t, 10, perPage)
+	assert.Equal(t, 1, page)
+}
+
+func TestPaginatorError(t *testing.T) {
+	paginator := &Paginator{MaxPerPage: 100, MaxPage: 5, Policy: LimitError}
+
+	tests := map[string]struct {
+		perPage   string
+		page      string
+		wantParam string
+		wantMsg   string
+	}{
+		"per_page too large": {"101", "1", "per_page", "Invalid parameter per_page: must be at most 100"},
+		"per_page zero":      {"0", "1", "per_page", "Invalid parameter per_page: must be at least 1"},
+		"page too large":     {"10", "6", "page", "Invalid parameter page: must be at most 5"},
+		"page zero":          {"10", "0", "page", "Invalid parameter page: must be at least 1"},
+		"page not an int":    {"10", "b", "page", "Invalid parameter page: not an int"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, _, _, err := paginator.Paginate(tt.perPage, tt.page)
+
+			var paginationErr *PaginationError
+			assert.True(t, errors.As(err, &paginationErr))
+			assert.Equal(t, tt.wantParam, paginationErr.Param)
+			assert.Equal(t, tt.wantMsg, err.Error())
+		})
+	}
+}
+
+func TestPaginatorOverflow(t *testing.T) {
+	maxPage := math.MaxInt/10 + 1
+
+	offset, perPage, page, err := (&Paginator{}).Paginate("10", strconv.Itoa(math.MaxInt))
+	assert.NoError(t, err)
+	assert.Equal(t, 10, perPage)
+	assert.Equal(t, maxPage, page)
+	assert.Equal(t, (maxPage-1)*10, offset)
+	assert.True(t, offset >= 0)
+
+	_, _, _, err = (&Paginator{Policy: LimitError}).Paginate("10", strconv.Itoa(math.MaxInt))
+	assert.EqualError(t, err, "Invalid parameter page: must be at most "+strconv.Itoa(maxPage))
+}
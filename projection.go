@@ -0,0 +1,201 @@
+package helpers
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Projection represent the sparse fieldset of a request e.g. "?fields=id,name",
+// mapping the requested JSON field names to the db column names of the model.
+type Projection struct {
+	// Fields is the list of the requested field names of the external tag e.g. "json".
+	Fields []string
+	// Columns is the list of the column names of the internal tag e.g. "db"
+	// of the requested fields. The fields without internal tag, the relation
+	// fields e.g. `json:"author"` of type *Author and the dotted fields of the
+	// nested structs are only kept in Fields.
+	Columns []string
+
+	fields []*tagField
+}
+
+// NewProjection represent the helpers for build the projection of the
+// comma-separated JSON field names from the model "json" and "db" tags. Every
+// column of the model with both tags is projected when fields is empty, without
+// the fields of the nested structs. The error is an
+// *InvalidFieldsError for the unknown fields or ErrInvalidModel.
+func NewProjection(model interface{}, fields string) (*Projection, error) {
+	return NewProjectionWithMapping(model, fields, JSONToDB)
+}
+
+// NewProjectionWithMapping represent the helpers for build the projection like
+// NewProjection, the fields are named by the external tag and the columns are
+// named by the internal tag of the same struct field.
+func NewProjectionWithMapping(model interface{}, fields string, mapping TagMapping) (*Projection, error) {
+	modelFields, err := modelMappedFields(model, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Projection{Fields: []string{}, Columns: []string{}}
+	if strings.TrimSpace(fields) == "" {
+		for _, f := range modelFields.list {
+			if f.column() {
+				p.add(f)
+			}
+		}
+
+		return p, nil
+	}
+
+	rejected := []string{}
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(fields, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		f, ok := modelFields.byName[name]
+		if !ok {
+			rejected = append(rejected, name)
+			continue
+		}
+
+		p.add(f)
+	}
+
+	if len(rejected) > 0 {
+		return nil, &InvalidFieldsError{
+			Param:    "fields",
+			Rejected: rejected,
+			Allowed:  modelFields.external.allowedNames(""),
+		}
+	}
+
+	return p, nil
+}
+
+// Select returns the double-quoted column list of the projection e.g. `"id", "name"`.
+func (p *Projection) Select() string {
+	return p.RenderSelect(Postgres)
+}
+
+// RenderSelect returns the column list of the projection quoted in the dialect.
+func (p *Projection) RenderSelect(d Dialect) string {
+	columns := make([]string, 0, len(p.Columns))
+	for _, column := range p.Columns {
+		columns = append(columns, d.QuoteIdent(column))
+	}
+
+	return strings.Join(columns, ", ")
+}
+
+// Trim returns the given struct, map or slice of them trimmed down to the
+// requested fields. The structs are converted into map[string]interface{}
+// and the dotted fields into nested maps.
+func (p *Projection) Trim(v interface{}) (interface{}, error) {
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return nil, nil
+		}
+
+		val = val.Elem()
+	}
+
+	switch val.Kind() {
+	case reflect.Slice, reflect.Array:
+		out := make([]interface{}, 0, val.Len())
+		for i := 0; i < val.Len(); i++ {
+			item, err := p.Trim(val.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+
+			out = append(out, item)
+		}
+
+		return out, nil
+	case reflect.Map:
+		m, ok := val.Interface().(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("projection cannot trim %T", v)
+		}
+
+		out := make(map[string]interface{}, len(p.Fields))
+		for _, name := range p.Fields {
+			if value, ok := lookupPath(m, name); ok {
+				setPath(out, name, value)
+			}
+		}
+
+		return out, nil
+	case reflect.Struct:
+		out := make(map[string]interface{}, len(p.fields))
+		for _, f := range p.fields {
+			if value, ok := fieldByIndex(val, f.index); ok && value.CanInterface() {
+				setPath(out, f.name, value.Interface())
+			}
+		}
+
+		return out, nil
+	}
+
+	return nil, fmt.Errorf("projection cannot trim %T", v)
+}
+
+// add appends the field and its column to the projection.
+func (p *Projection) add(f *mappedField) {
+	p.Fields = append(p.Fields, f.external.name)
+	p.fields = append(p.fields, f.external)
+	if f.column() {
+		p.Columns = append(p.Columns, f.internal.name)
+	}
+}
+
+// column reports whether the field is a column of the model table, a field
+// with an internal tag which is neither a relation nor in a nested struct.
+func (f *mappedField) column() bool {
+	return f.internal != nil && !strings.Contains(f.internal.name, ".") && !f.internal.relation()
+}
+
+// lookupPath returns the value of the dotted path in the nested maps.
+func lookupPath(m map[string]interface{}, path string) (interface{}, bool) {
+	name, rest, nested := strings.Cut(path, ".")
+	value, ok := m[name]
+	if !ok || !nested {
+		return value, ok
+	}
+
+	child, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	return lookupPath(child, rest)
+}
+
+// setPath sets the value of the dotted path in the nested maps. The value is
+// skipped when a parent of the path already holds a value.
+func setPath(m map[string]interface{}, path string, value interface{}) {
+	name, rest, nested := strings.Cut(path, ".")
+	if !nested {
+		m[name] = value
+		return
+	}
+
+	child, ok := m[name].(map[string]interface{})
+	if !ok {
+		if _, exists := m[name]; exists {
+			return
+		}
+
+		child = make(map[string]interface{})
+		m[name] = child
+	}
+
+	setPath(child, rest, value)
+}
@@ -0,0 +1,100 @@
+package helpers
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ArticleModel struct {
+	ID        string       `json:"id" db:"id"`
+	Title     string       `json:"title" db:"title"`
+	Order     int          `json:"position" db:"order"`
+	Author    *AuthorModel `json:"author,nested" db:"author,nested"`
+	WordCount int          `json:"word_count"`
+	CreatedAt time.Time    `json:"created_at" db:"created_at"`
+}
+
+func TestNewProjection(t *testing.T) {
+	p, err := NewProjection(ArticleModel{}, "id, position,word_count,id")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"id", "position", "word_count"}, p.Fields)
+	assert.Equal(t, []string{"id", "order"}, p.Columns)
+	assert.Equal(t, `"id", "order"`, p.Select())
+}
+
+func TestNewProjectionAllFields(t *testing.T) {
+	p, err := NewProjection(&ArticleModel{}, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"id", "title", "position", "created_at"}, p.Fields)
+	assert.Equal(t, `"id", "title", "order", "created_at"`, p.Select())
+}
+
+func TestNewProjectionNestedField(t *testing.T) {
+	p, err := NewProjection(ArticleModel{}, "id,author.name")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"id", "author.name"}, p.Fields)
+	assert.Equal(t, []string{"id"}, p.Columns)
+	assert.Equal(t, `"id"`, p.Select())
+}
+
+func TestNewProjectionRelationField(t *testing.T) {
+	p, err := NewProjection(CommentModel{}, "id,author")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"id", "author"}, p.Fields)
+	assert.Equal(t, []string{"id"}, p.Columns)
+
+	author := &AuthorModel{Name: "bar"}
+	out, err := p.Trim(CommentModel{ID: "1", Author: author})
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"id": "1", "author": author}, out)
+}
+
+func TestNewProjectionUnknownField(t *testing.T) {
+	_, err := NewProjection(ArticleModel{}, "id,x")
+
+	var fieldsErr *InvalidFieldsError
+	assert.True(t, errors.As(err, &fieldsErr))
+	assert.Equal(t, "fields", fieldsErr.Param)
+	assert.Equal(t, []string{"x"}, fieldsErr.Rejected)
+
+	_, err = NewProjection(nil, "id")
+	assert.Equal(t, ErrInvalidModel, err)
+}
+
+func TestProjectionTrimStruct(t *testing.T) {
+	p, _ := NewProjection(ArticleModel{}, "id,author.name,word_count")
+	article := &ArticleModel{ID: "1", Title: "foo", Author: &AuthorModel{Name: "bar"}, WordCount: 3}
+
+	out, err := p.Trim([]*ArticleModel{article, {ID: "2"}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"id": "1", "author": map[string]interface{}{"name": "bar"}, "word_count": 3},
+		map[string]interface{}{"id": "2", "word_count": 0},
+	}, out)
+}
+
+func TestProjectionTrimMap(t *testing.T) {
+	p, _ := NewProjection(ArticleModel{}, "id,author.name")
+	m := map[string]interface{}{
+		"id":     "1",
+		"title":  "foo",
+		"author": map[string]interface{}{"name": "bar", "email": "baz"},
+	}
+
+	out, err := p.Trim(m)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"id": "1", "author": map[string]interface{}{"name": "bar"}}, out)
+
+	_, err = p.Trim(1)
+	assert.Error(t, err)
+}
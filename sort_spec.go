@@ -0,0 +1,164 @@
+package helpers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SortDirection represent the direction of a sort column
+type SortDirection string
+
+const (
+	// SortAsc sorts the column in ascending order.
+	SortAsc SortDirection = "ASC"
+	// SortDesc sorts the column in descending order.
+	SortDesc SortDirection = "DESC"
+)
+
+// NullsOrder represent the placement of NULL values of a sort column
+type NullsOrder string
+
+const (
+	// NullsDefault leaves the NULL placement to the database.
+	NullsDefault NullsOrder = ""
+	// NullsFirst places the NULL values before the other values.
+	NullsFirst NullsOrder = "NULLS FIRST"
+	// NullsLast places the NULL values after the other values.
+	NullsLast NullsOrder = "NULLS LAST"
+)
+
+// SortSpec represent a single validated column of an order by query
+type SortSpec struct {
+	Column    string
+	Direction SortDirection
+	Nulls     NullsOrder
+}
+
+// String returns the order by fragment of the sort column e.g. "name DESC NULLS LAST".
+func (s SortSpec) String() string {
+	direction := s.Direction
+	if direction == "" {
+		direction = SortAsc
+	}
+
+	if s.Nulls == NullsDefault {
+		return fmt.Sprintf("%s %s", s.Column, direction)
+	}
+
+	return fmt.Sprintf("%s %s %s", s.Column, direction, s.Nulls)
+}
+
+// InvalidFieldsError represent the error for the requested fields which are not
+// allowed by the model e.g. an unknown sort column.
+type InvalidFieldsError struct {
+	// Param is the name of the request parameter holding the fields.
+	Param string
+	// Rejected is the list of the requested fields which are not allowed.
+	Rejected []string
+	// Allowed is the list of the fields accepted by the model.
+	Allowed []string
+}
+
+// Error returns the error message listing the rejected and the allowed fields.
+func (e *InvalidFieldsError) Error() string {
+	return fmt.Sprintf(
+		"Invalid parameter %s: unknown field(s) %s, allowed: %s",
+		e.Param, strings.Join(e.Rejected, ", "), strings.Join(e.Allowed, ", "),
+	)
+}
+
+// ParseSort represent the helpers for parse a comma-separated sort query into
+// the list of SortSpec. Each field accepts the "-field", "+field", "field:desc",
+// "field:asc" forms with an optional ":nulls_first" or ":nulls_last" suffix
+// e.g. "-created_at,name:asc:nulls_last". Duplicated columns are ignored. When a
+// field of the model is marked "sortable" in the tag, only the marked fields are allowed.
+// The valid columns are always returned, the error is an *InvalidFieldsError
+// when one or more fields are not found in the model tag, or ErrInvalidModel.
+func ParseSort(fields, tag string, model interface{}) ([]SortSpec, error) {
+	return ParseSortWithMapping(fields, TagMapping{External: tag, Internal: tag}, model)
+}
+
+// ParseSortWithMapping represent the helpers for parse a sort query like
+// ParseSort, the fields are named by the external tag e.g. "json" and the
+// columns are named by the internal tag e.g. "db" of the same struct field.
+func ParseSortWithMapping(fields string, mapping TagMapping, model interface{}) ([]SortSpec, error) {
+	modelFields, err := modelMappedFields(model, mapping)
+	if err != nil {
+		return []SortSpec{}, err
+	}
+
+	specs := []SortSpec{}
+	rejected := []string{}
+	seen := make(map[string]bool)
+	for _, f := range strings.Split(fields, ",") {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+
+		spec := parseSortField(f)
+		field, ok := modelFields.lookup(spec.Column, TagOptionSortable)
+		if !ok {
+			rejected = append(rejected, spec.Column)
+			continue
+		}
+
+		column := field.internal.name
+
+		if seen[column] {
+			continue
+		}
+		seen[column] = true
+
+		spec.Column = column
+		specs = append(specs, spec)
+	}
+
+	if len(rejected) > 0 {
+		return specs, &InvalidFieldsError{
+			Param:    "sort",
+			Rejected: rejected,
+			Allowed:  modelFields.allowedNames(TagOptionSortable),
+		}
+	}
+
+	return specs, nil
+}
+
+// OrderBy returns the order by fragment of the given sort columns e.g. "id DESC, name ASC".
+func OrderBy(specs []SortSpec) string {
+	return RenderOrderBy(rawDialect, specs)
+}
+
+// parseSortField splits a single sort field into its name, direction and
+// nulls placement. The field is returned as-is when a modifier is unknown.
+func parseSortField(field string) SortSpec {
+	spec := SortSpec{Direction: SortAsc}
+	switch {
+	case strings.HasPrefix(field, "-"):
+		spec.Direction = SortDesc
+		field = field[1:]
+	case strings.HasPrefix(field, "+"):
+		field = field[1:]
+	}
+
+	parts := strings.Split(field, ":")
+	spec.Column = parts[0]
+	for _, modifier := range parts[1:] {
+		switch strings.ToLower(modifier) {
+		case "asc":
+			spec.Direction = SortAsc
+		case "desc":
+			spec.Direction = SortDesc
+		case "nulls_first", "nullsfirst":
+			spec.Nulls = NullsFirst
+		case "nulls_last", "nullslast":
+			spec.Nulls = NullsLast
+		default:
+			spec.Column = field
+			return spec
+		}
+	}
+
+	return spec
+}
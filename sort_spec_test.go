@@ -0,0 +1,75 @@
+package helpers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSort(t *testing.T) {
+	specs, err := ParseSort("-created_at,name:asc:nulls_last,phone:nullsfirst", "db", UserModel{})
+	expectedSpecs := []SortSpec{
+		{Column: "created_at", Direction: SortDesc},
+		{Column: "name", Direction: SortAsc, Nulls: NullsLast},
+		{Column: "phone", Direction: SortAsc, Nulls: NullsFirst},
+	}
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedSpecs, specs)
+	assert.Equal(t, "created_at DESC, name ASC NULLS LAST, phone ASC NULLS FIRST", OrderBy(specs))
+}
+
+func TestParseSortInvalidFields(t *testing.T) {
+	specs, err := ParseSort("id,x,-y", "db", UserModel{})
+
+	var fieldsErr *InvalidFieldsError
+	assert.True(t, errors.As(err, &fieldsErr))
+	assert.Equal(t, "sort", fieldsErr.Param)
+	assert.Equal(t, []string{"x", "y"}, fieldsErr.Rejected)
+	assert.Equal(t, []string{"id", "name", "email", "phone", "address", "created_at", "updated_at", "deleted_at"}, fieldsErr.Allowed)
+	assert.Equal(t, []SortSpec{{Column: "id", Direction: SortAsc}}, specs)
+}
+
+func TestParseSortEmptyField(t *testing.T) {
+	specs, err := ParseSort("", "db", UserModel{})
+
+	assert.NoError(t, err)
+	assert.Empty(t, specs)
+}
+
+func TestSortSpecString(t *testing.T) {
+	spec := SortSpec{Column: "id"}
+
+	assert.Equal(t, "id ASC", spec.String())
+}
+
+func TestInvalidFieldsErrorMessage(t *testing.T) {
+	err := &InvalidFieldsError{Param: "sort", Rejected: []string{"x"}, Allowed: []string{"id", "name"}}
+	expectedMsg := "Invalid parameter sort: unknown field(s) x, allowed: id, name"
+
+	assert.Equal(t, expectedMsg, err.Error())
+}
+
+func TestParseSortEmbeddedAndNested(t *testing.T) {
+	specs, err := ParseSort("-created_at,author.name", "db", &PostModel{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "created_at DESC, author.name ASC", OrderBy(specs))
+}
+
+func TestParseSortRelationNotColumn(t *testing.T) {
+	specs, err := ParseSort("author,author.name,id", "db", CommentModel{})
+
+	var fieldsErr *InvalidFieldsError
+	assert.True(t, errors.As(err, &fieldsErr))
+	assert.Equal(t, []string{"author", "author.name"}, fieldsErr.Rejected)
+	assert.Equal(t, []string{"id", "note"}, fieldsErr.Allowed)
+	assert.Equal(t, "id ASC", OrderBy(specs))
+}
+
+func TestParseSortInvalidModel(t *testing.T) {
+	_, err := ParseSort("id", "db", "user")
+
+	assert.Equal(t, ErrInvalidModel, err)
+}
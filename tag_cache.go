@@ -0,0 +1,219 @@
+package helpers
+
+import (
+	"database/sql/driver"
+	"encoding"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInvalidModel is an error message when the model is not a struct or a pointer to a struct.
+var ErrInvalidModel = errors.New("model must be a struct or a pointer to a struct")
+
+// tagField is the metadata of a struct field with a tag value.
+type tagField struct {
+	// name is the tag value, a dotted path for the fields of the nested structs e.g. "author.name".
+	name    string
+	options TagOptions
+	index   []int
+	depth   int
+	field   reflect.StructField
+}
+
+// tagFields is the metadata of the struct fields for a tag, in the struct order.
+type tagFields struct {
+	list   []*tagField
+	byName map[string]*tagField
+	// options is the set of the tag options carried by at least one field.
+	options map[string]bool
+}
+
+// tagCacheKey is the key of the tag metadata cache.
+type tagCacheKey struct {
+	t   reflect.Type
+	tag string
+}
+
+// tagCache caches the tagFields per struct type and tag, safe for concurrent use.
+var tagCache sync.Map
+
+var (
+	// timeType is the reflect type of time.Time which is never walked as a nested struct.
+	timeType = reflect.TypeOf(time.Time{})
+	// valuerType is the reflect type of driver.Valuer.
+	valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	// textMarshalerType is the reflect type of encoding.TextMarshaler.
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// cachedTagFields returns the cached metadata of the struct fields of the type for the given tag.
+func cachedTagFields(t reflect.Type, tag string) *tagFields {
+	key := tagCacheKey{t, tag}
+	if f, ok := tagCache.Load(key); ok {
+		return f.(*tagFields)
+	}
+
+	f, _ := tagCache.LoadOrStore(key, buildTagFields(t, tag))
+
+	return f.(*tagFields)
+}
+
+// buildTagFields walks the struct fields of the type for the given tag. The
+// tag value is split into the name and the options, e.g. `db:"name,sortable"`. The
+// fields of the anonymous embedded structs without tag are flattened. A nested
+// struct is kept as a relation field, its fields are also named by their dotted
+// path when it is marked "nested" e.g. `db:"author,nested"`. A shallower field
+// shadows the deeper fields with the same name, like encoding/json.
+func buildTagFields(t reflect.Type, tag string) *tagFields {
+	walked := []*tagField{}
+	walkTagFields(t, tag, "", nil, 0, map[reflect.Type]bool{}, &walked)
+
+	f := &tagFields{byName: make(map[string]*tagField), options: make(map[string]bool)}
+	for _, field := range walked {
+		if current, ok := f.byName[field.name]; !ok || field.depth < current.depth {
+			f.byName[field.name] = field
+		}
+	}
+
+	for _, field := range walked {
+		if f.byName[field.name] == field {
+			f.list = append(f.list, field)
+
+			for _, option := range strings.Split(string(field.options), ",") {
+				if option = strings.TrimSpace(option); option != "" {
+					f.options[option] = true
+				}
+			}
+		}
+	}
+
+	return f
+}
+
+// walkTagFields appends the fields of the struct type with the given tag to out.
+func walkTagFields(
+	t reflect.Type, tag, prefix string, index []int, depth int, visiting map[reflect.Type]bool, out *[]*tagField,
+) {
+	if visiting[t] {
+		return
+	}
+
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		fieldIndex := append(append([]int{}, index...), i)
+		fieldType := indirectType(sf.Type)
+		value, ok := sf.Tag.Lookup(tag)
+		name, options := ParseTag(value)
+
+		if sf.Anonymous && (!ok || name == "") && fieldType.Kind() == reflect.Struct {
+			walkTagFields(fieldType, tag, prefix, fieldIndex, depth+1, visiting, out)
+			continue
+		}
+
+		if !ok || name == "" || name == "-" {
+			continue
+		}
+
+		f := &tagField{
+			name:    prefix + name,
+			options: options,
+			index:   fieldIndex,
+			depth:   depth,
+			field:   sf,
+		}
+		*out = append(*out, f)
+
+		if f.relation() && f.exported() && options.Contains(TagOptionNested) {
+			walkTagFields(fieldType, tag, prefix+name+".", fieldIndex, depth+1, visiting, out)
+		}
+	}
+}
+
+// relation reports whether the field is a nested struct which is not stored
+// as a single column e.g. a `db:"author"` field of type *Author.
+func (f *tagField) relation() bool {
+	t := indirectType(f.field.Type)
+
+	return t.Kind() == reflect.Struct && !isValueStruct(t)
+}
+
+// exported reports whether the value of the field could be read from the
+// struct, the unexported fields are only known by their name.
+func (f *tagField) exported() bool {
+	return f.field.PkgPath == ""
+}
+
+// isValueStruct reports whether the struct type is stored as a single column
+// e.g. time.Time, sql.NullString or a type implementing encoding.TextMarshaler.
+func isValueStruct(t reflect.Type) bool {
+	if t == timeType {
+		return true
+	}
+
+	for _, typ := range []reflect.Type{t, reflect.PtrTo(t)} {
+		if typ.Implements(valuerType) || typ.Implements(textMarshalerType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// modelTagFields returns the cached metadata of the struct fields of the model
+// for the given tag. The model could be a struct or a pointer to a struct.
+func modelTagFields(model interface{}, tag string) (*tagFields, error) {
+	t, err := modelType(model)
+	if err != nil {
+		return nil, err
+	}
+
+	return cachedTagFields(t, tag), nil
+}
+
+// modelType returns the struct type of the model, a struct or a pointer to a struct.
+func modelType(model interface{}) (reflect.Type, error) {
+	t := reflect.TypeOf(model)
+	if t == nil {
+		return nil, ErrInvalidModel
+	}
+
+	t = indirectType(t)
+	if t.Kind() != reflect.Struct {
+		return nil, ErrInvalidModel
+	}
+
+	return t, nil
+}
+
+// indirectType returns the type pointed to by the pointer types.
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t
+}
+
+// fieldByIndex returns the nested field of the struct value, false when a
+// pointer on the way is nil.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for _, i := range index {
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+
+			v = v.Elem()
+		}
+
+		v = v.Field(i)
+	}
+
+	return v, true
+}
@@ -0,0 +1,141 @@
+package helpers
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// TagMapping represent a pair of tag namespaces on the same struct fields, the
+// External tag used by the clients e.g. "json" and the Internal tag used by the
+// storage e.g. "db".
+type TagMapping struct {
+	External string
+	Internal string
+}
+
+// JSONToDB is the TagMapping from the "json" tag to the "db" tag.
+var JSONToDB = TagMapping{External: "json", Internal: "db"}
+
+// mappedField is a struct field with its external and internal tag metadata.
+// internal is nil when the field has no internal tag.
+type mappedField struct {
+	external *tagField
+	internal *tagField
+}
+
+// mappedFields is the metadata of the struct fields for a TagMapping, in the struct order.
+type mappedFields struct {
+	list     []*mappedField
+	byName   map[string]*mappedField
+	external *tagFields
+	internal *tagFields
+}
+
+// mappingCacheKey is the key of the tag mapping metadata cache.
+type mappingCacheKey struct {
+	t       reflect.Type
+	mapping TagMapping
+}
+
+// mappingCache caches the mappedFields per struct type and TagMapping, safe for concurrent use.
+var mappingCache sync.Map
+
+// TranslateTag represent the helpers for translate a field name from a tag to
+// another tag of the same struct field e.g. "createdAt" from "json" into
+// "created_at" from "db". An empty string is returned when the field is not
+// found in one of the tags. The error is ErrInvalidModel.
+func TranslateTag(v interface{}, field, fromTag, toTag string) (string, error) {
+	return TagMapping{External: fromTag, Internal: toTag}.Translate(v, field)
+}
+
+// Translate translates the external field name into the internal field name.
+func (m TagMapping) Translate(v interface{}, field string) (string, error) {
+	fields, err := modelMappedFields(v, m)
+	if err != nil {
+		return "", err
+	}
+
+	f, ok := fields.byName[field]
+	if !ok || f.internal == nil {
+		return "", nil
+	}
+
+	return f.internal.name, nil
+}
+
+// modelMappedFields returns the cached metadata of the struct fields of the model for the TagMapping.
+func modelMappedFields(model interface{}, m TagMapping) (*mappedFields, error) {
+	t, err := modelType(model)
+	if err != nil {
+		return nil, err
+	}
+
+	key := mappingCacheKey{t, m}
+	if f, ok := mappingCache.Load(key); ok {
+		return f.(*mappedFields), nil
+	}
+
+	f, _ := mappingCache.LoadOrStore(key, buildMappedFields(t, m))
+
+	return f.(*mappedFields), nil
+}
+
+// buildMappedFields pairs the external and the internal tag fields by their struct field index.
+func buildMappedFields(t reflect.Type, m TagMapping) *mappedFields {
+	f := &mappedFields{
+		byName:   make(map[string]*mappedField),
+		external: cachedTagFields(t, m.External),
+		internal: cachedTagFields(t, m.Internal),
+	}
+
+	internal := make(map[string]*tagField, len(f.internal.list))
+	for _, field := range f.internal.list {
+		internal[indexKey(field.index)] = field
+	}
+
+	for _, field := range f.external.list {
+		mf := &mappedField{external: field, internal: internal[indexKey(field.index)]}
+		f.list = append(f.list, mf)
+		f.byName[field.name] = mf
+	}
+
+	return f
+}
+
+// lookup returns the column field with the external name which is allowed
+// for the given tag option.
+func (f *mappedFields) lookup(name, option string) (*mappedField, bool) {
+	mf, ok := f.byName[name]
+	if !ok || !f.allows(mf, option) {
+		return nil, false
+	}
+
+	return mf, true
+}
+
+// allows reports whether the field has an internal tag which is not a
+// relation, and is allowed for the tag option on both the external and the
+// internal tags.
+func (f *mappedFields) allows(mf *mappedField, option string) bool {
+	return mf.internal != nil && !mf.internal.relation() &&
+		f.external.allows(mf.external, option) &&
+		f.internal.allows(mf.internal, option)
+}
+
+// allowedNames returns the list of the external names allowed for the given tag option.
+func (f *mappedFields) allowedNames(option string) []string {
+	out := []string{}
+	for _, mf := range f.list {
+		if f.allows(mf, option) {
+			out = append(out, mf.external.name)
+		}
+	}
+
+	return out
+}
+
+// indexKey returns the key of a struct field index.
+func indexKey(index []int) string {
+	return fmt.Sprint(index)
+}
@@ -0,0 +1,81 @@
+package helpers
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type AccountModel struct {
+	ID        string    `json:"id" db:"account_id,sortable"`
+	FullName  string    `json:"fullName" db:"full_name,sortable"`
+	Balance   int       `json:"balance" db:"balance,filterable"`
+	Secret    string    `json:"-" db:"secret"`
+	CreatedAt time.Time `json:"createdAt" db:"created_at,sortable,filterable"`
+}
+
+func TestTranslateTag(t *testing.T) {
+	column, err := TranslateTag(AccountModel{}, "createdAt", "json", "db")
+	assert.NoError(t, err)
+	assert.Equal(t, "created_at", column)
+
+	column, err = JSONToDB.Translate(&AccountModel{}, "fullName")
+	assert.NoError(t, err)
+	assert.Equal(t, "full_name", column)
+
+	column, err = TranslateTag(AccountModel{}, "full_name", "db", "json")
+	assert.NoError(t, err)
+	assert.Equal(t, "fullName", column)
+
+	column, err = TranslateTag(AccountModel{}, "secret", "db", "json")
+	assert.NoError(t, err)
+	assert.Equal(t, "", column)
+
+	_, err = TranslateTag("account", "id", "json", "db")
+	assert.Equal(t, ErrInvalidModel, err)
+}
+
+func TestParseSortWithMapping(t *testing.T) {
+	specs, err := ParseSortWithMapping("-createdAt,fullName", JSONToDB, AccountModel{})
+	assert.NoError(t, err)
+	assert.Equal(t, "created_at DESC, full_name ASC", OrderBy(specs))
+
+	_, err = ParseSortWithMapping("created_at,balance", JSONToDB, AccountModel{})
+
+	var fieldsErr *InvalidFieldsError
+	assert.True(t, errors.As(err, &fieldsErr))
+	assert.Equal(t, []string{"created_at", "balance"}, fieldsErr.Rejected)
+	assert.Equal(t, []string{"id", "fullName", "createdAt"}, fieldsErr.Allowed)
+}
+
+func TestParseFilterWithMapping(t *testing.T) {
+	values := url.Values{"filter[balance][gte]": {"100"}}
+	conditions, err := ParseFilterWithMapping(values, AccountModel{}, JSONToDB)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []FilterCondition{{Column: "balance", Operator: FilterGte, Value: 100}}, conditions)
+
+	_, err = ParseFilterWithMapping(url.Values{"filter[fullName]": {"foo"}}, AccountModel{}, JSONToDB)
+
+	var fieldsErr *InvalidFieldsError
+	assert.True(t, errors.As(err, &fieldsErr))
+	assert.Equal(t, []string{"balance", "createdAt"}, fieldsErr.Allowed)
+}
+
+func TestNewProjectionWithMapping(t *testing.T) {
+	p, err := NewProjectionWithMapping(AccountModel{}, "id,createdAt", JSONToDB)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"id", "createdAt"}, p.Fields)
+	assert.Equal(t, `"account_id", "created_at"`, p.Select())
+
+	p, err = NewProjectionWithMapping(AccountModel{}, "account_id", TagMapping{External: "db", Internal: "db"})
+	assert.NoError(t, err)
+
+	out, err := p.Trim(AccountModel{ID: "1", FullName: "foo"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"account_id": "1"}, out)
+}
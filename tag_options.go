@@ -0,0 +1,100 @@
+package helpers
+
+import "strings"
+
+const (
+	// TagOptionSortable is the tag option marking a field as sortable e.g. `db:"name,sortable"`.
+	TagOptionSortable = "sortable"
+	// TagOptionFilterable is the tag option marking a field as filterable e.g. `db:"name,filterable"`.
+	TagOptionFilterable = "filterable"
+	// TagOptionNested is the tag option exposing the fields of a nested struct
+	// by their dotted path e.g. "author.name" for `db:"author,nested"`.
+	TagOptionNested = "nested"
+)
+
+// TagOptions represent the comma-separated options of a struct tag following the
+// tag name e.g. "omitempty,sortable" for `json:"name,omitempty,sortable"`.
+type TagOptions string
+
+// ParseTag splits a struct tag into its name and its options.
+func ParseTag(tag string) (string, TagOptions) {
+	name, options, _ := strings.Cut(tag, ",")
+
+	return name, TagOptions(options)
+}
+
+// Contains reports whether the options contain the given option.
+func (o TagOptions) Contains(option string) bool {
+	if len(o) == 0 {
+		return false
+	}
+
+	s := string(o)
+	for s != "" {
+		var name string
+		name, s, _ = strings.Cut(s, ",")
+		if strings.TrimSpace(name) == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LookupTagOptions returns the tag options of the model field with the given tag name.
+// The error is ErrInvalidModel when v is not a struct or a pointer to a struct.
+func LookupTagOptions(v interface{}, field, tag string) (TagOptions, bool, error) {
+	fields, err := modelTagFields(v, tag)
+	if err != nil {
+		return "", false, err
+	}
+
+	f, ok := fields.byName[field]
+	if !ok {
+		return "", false, nil
+	}
+
+	return f.options, true, nil
+}
+
+// CheckInTagOption represent the helpers for check attribute inside of struct
+// which are marked with the given tag option e.g. "filterable". The markers
+// are opt-in per model: when no field of the model carries the option, every
+// field is allowed like CheckInTag.
+func CheckInTagOption(v interface{}, filterField, tag, option string) []string {
+	fields, err := modelTagFields(v, tag)
+	if err != nil {
+		return []string{}
+	}
+
+	out := []string{}
+	for _, name := range CheckInTag(v, filterField, tag) {
+		if fields.allows(fields.byName[name], option) {
+			out = append(out, name)
+		}
+	}
+
+	return out
+}
+
+// allows reports whether the field is allowed for the given tag option. Every
+// field is allowed when no field of the model carries the option.
+func (f *tagFields) allows(field *tagField, option string) bool {
+	if option == "" || !f.options[option] {
+		return true
+	}
+
+	return field.options.Contains(option)
+}
+
+// allowedNames returns the list of the field names allowed for the given tag option.
+func (f *tagFields) allowedNames(option string) []string {
+	out := []string{}
+	for _, field := range f.list {
+		if f.allows(field, option) {
+			out = append(out, field.name)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,81 @@
+package helpers
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ProductModel struct {
+	ID    string  `json:"id,omitempty" db:"id,sortable,filterable"`
+	Name  string  `json:"name,omitempty" db:"name,sortable"`
+	Price float64 `json:"price" db:"price,filterable"`
+	Notes string  `json:"notes" db:"notes"`
+}
+
+func TestParseTag(t *testing.T) {
+	name, options := ParseTag("name,omitempty,sortable")
+
+	assert.Equal(t, "name", name)
+	assert.True(t, options.Contains("omitempty"))
+	assert.True(t, options.Contains("sortable"))
+	assert.False(t, options.Contains("filterable"))
+
+	name, options = ParseTag("name")
+	assert.Equal(t, "name", name)
+	assert.False(t, options.Contains(""))
+}
+
+func TestCheckMatchTagWithOptions(t *testing.T) {
+	assert.Equal(t, "name", CheckMatchTag(ProductModel{}, "name", "json"))
+	assert.Equal(t, []string{"id", "name"}, CheckInTag(ProductModel{}, "id,name", "json"))
+}
+
+func TestLookupTagOptions(t *testing.T) {
+	options, ok, err := LookupTagOptions(ProductModel{}, "id", "db")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, TagOptions("sortable,filterable"), options)
+
+	_, ok, err = LookupTagOptions(ProductModel{}, "x", "db")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, err = LookupTagOptions(1, "id", "db")
+	assert.Equal(t, ErrInvalidModel, err)
+}
+
+func TestCheckInTagOption(t *testing.T) {
+	res := CheckInTagOption(ProductModel{}, "id,name,price,notes", "db", TagOptionFilterable)
+	assert.Equal(t, []string{"id", "price"}, res)
+
+	res = CheckInTagOption(UserModel{}, "id,name", "db", TagOptionFilterable)
+	assert.Equal(t, []string{"id", "name"}, res)
+}
+
+func TestParseSortSortableOption(t *testing.T) {
+	assert.Equal(t, "name DESC", OrderByHandler("-name", "db", ProductModel{}))
+	assert.Equal(t, "", OrderByHandler("price", "db", ProductModel{}))
+
+	_, err := ParseSort("price", "db", ProductModel{})
+
+	var fieldsErr *InvalidFieldsError
+	assert.True(t, errors.As(err, &fieldsErr))
+	assert.Equal(t, []string{"price"}, fieldsErr.Rejected)
+	assert.Equal(t, []string{"id", "name"}, fieldsErr.Allowed)
+}
+
+func TestParseFilterFilterableOption(t *testing.T) {
+	conditions, err := ParseFilter(url.Values{"filter[price][gt]": {"10"}}, ProductModel{}, "db")
+	assert.NoError(t, err)
+	assert.Equal(t, []FilterCondition{{Column: "price", Operator: FilterGt, Value: 10.0}}, conditions)
+
+	_, err = ParseFilter(url.Values{"filter[name]": {"foo"}}, ProductModel{}, "db")
+
+	var fieldsErr *InvalidFieldsError
+	assert.True(t, errors.As(err, &fieldsErr))
+	assert.Equal(t, []string{"name"}, fieldsErr.Rejected)
+	assert.Equal(t, []string{"id", "price"}, fieldsErr.Allowed)
+}